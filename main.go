@@ -1,16 +1,29 @@
 package main
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"context"
+	"flag"
+	"log"
 
-	"terraform-provider-placeos/placeos"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+
+	"github.com/cacique-coder/terraform-placeos-provider/placeos"
 )
 
+// version is set by goreleaser at build time.
+var version = "dev"
+
 func main() {
-	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: func() *schema.Provider {
-			return placeos.Provider()
-		},
+	var debug bool
+
+	flag.BoolVar(&debug, "debug", false, "run the provider with support for debuggers like delve")
+	flag.Parse()
+
+	err := providerserver.Serve(context.Background(), placeos.New(version), providerserver.ServeOpts{
+		Address: "placeos.tech/edu/placeos",
+		Debug:   debug,
 	})
+	if err != nil {
+		log.Fatal(err.Error())
+	}
 }
@@ -0,0 +1,159 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// OAuth grants a client can authenticate with.
+const (
+	GrantTypePassword          = "password"
+	GrantTypeClientCredentials = "client_credentials"
+)
+
+// tokenRefreshWindow is how long before expiry the access token gets
+// refreshed, so requests never go out with a token about to lapse.
+const tokenRefreshWindow = 60 * time.Second
+
+// authorize requests a brand new access token with the configured grant.
+func (client *Client) authorize(ctx context.Context) error {
+	client.tokenMu.Lock()
+	defer client.tokenMu.Unlock()
+
+	return client.grant(ctx)
+}
+
+// accessToken returns a valid access token, refreshing it first when it is
+// close to expiring.
+func (client *Client) accessToken(ctx context.Context) (string, error) {
+	client.tokenMu.Lock()
+	defer client.tokenMu.Unlock()
+
+	if client.tokenExpiry.IsZero() || time.Now().Add(tokenRefreshWindow).Before(client.tokenExpiry) {
+		return client.Token.AccessToken, nil
+	}
+
+	if err := client.refresh(ctx); err != nil {
+		return "", err
+	}
+
+	return client.Token.AccessToken, nil
+}
+
+// reauthorize replaces a token rejected by the engine. When another request
+// already replaced stale in the meantime, the newer token is reused instead
+// of authenticating again.
+func (client *Client) reauthorize(ctx context.Context, stale string) (string, error) {
+	client.tokenMu.Lock()
+	defer client.tokenMu.Unlock()
+
+	if client.Token.AccessToken != stale {
+		return client.Token.AccessToken, nil
+	}
+
+	if err := client.grant(ctx); err != nil {
+		return "", err
+	}
+
+	return client.Token.AccessToken, nil
+}
+
+// refresh exchanges the refresh token for a new access token, falling back
+// to the configured grant when the engine did not issue a refresh token or
+// no longer accepts it. Callers must hold tokenMu.
+func (client *Client) refresh(ctx context.Context) error {
+	if client.Token.RefreshToken != "" {
+		err := client.requestToken(ctx, map[string]string{
+			"grant_type":    "refresh_token",
+			"refresh_token": client.Token.RefreshToken,
+		})
+		if err == nil {
+			return nil
+		}
+	}
+
+	return client.grant(ctx)
+}
+
+// grant authenticates with the client's GrantType. Callers must hold
+// tokenMu.
+func (client *Client) grant(ctx context.Context) error {
+	switch client.GrantType {
+	case GrantTypePassword:
+		return client.requestToken(ctx, map[string]string{
+			"grant_type": GrantTypePassword,
+			"username":   client.Username,
+			"password":   client.Password,
+			"scope":      client.Scope,
+		})
+	case GrantTypeClientCredentials:
+		return client.requestToken(ctx, map[string]string{
+			"grant_type": GrantTypeClientCredentials,
+			"scope":      client.Scope,
+		})
+	default:
+		return fmt.Errorf("the configured credentials cannot be renewed, the access token was rejected")
+	}
+}
+
+// requestToken posts params to the OAuth token endpoint and stores the
+// issued token. Callers must hold tokenMu.
+func (client *Client) requestToken(ctx context.Context, params map[string]string) error {
+	postBody, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/auth/oauth/token", client.Host), bytes.NewBuffer(postBody))
+	if err != nil {
+		return err
+	}
+
+	headerAuthorization := fmt.Sprintf("%s:%s", client.ClientId, client.ClientSecret)
+	encoded := base64.StdEncoding.EncodeToString([]byte(headerAuthorization))
+	req.Header.Add("Authorization", fmt.Sprintf("Basic %s", encoded))
+	req.Header.Add("Content-Type", "application/json")
+
+	r, err := client.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer r.Body.Close()
+
+	w, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+
+	if r.StatusCode < 200 || r.StatusCode > 299 {
+		return newAPIError(http.MethodPost, "/auth/oauth/token", r.StatusCode, w)
+	}
+
+	var accessToken AccessToken
+	if err := json.Unmarshal(w, &accessToken); err != nil {
+		return err
+	}
+	if accessToken.AccessToken == "" {
+		return fmt.Errorf("authorization response did not include an access token")
+	}
+
+	// the engine may omit created_at, fall back to the local clock
+	issuedAt := time.Now()
+	if accessToken.CreatedAt > 0 {
+		issuedAt = time.Unix(accessToken.CreatedAt, 0)
+	}
+
+	client.Token = accessToken
+	client.tokenExpiry = time.Time{}
+	if accessToken.ExpiresIn > 0 {
+		client.tokenExpiry = issuedAt.Add(time.Duration(accessToken.ExpiresIn) * time.Second)
+	}
+
+	return nil
+}
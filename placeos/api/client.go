@@ -0,0 +1,294 @@
+// Package api is a Go client for the PlaceOS engine REST API.
+//
+// A Client is created with one of the New*Client constructors, depending on
+// how it should authenticate, and exposes one service per engine resource:
+//
+//	client, err := api.NewApiKeyClient("https://placeos.example.com", key, api.TLSOptions{})
+//	system, err := client.Systems.Get(ctx, "sys-1234")
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type AccessToken struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+	Scope        string
+	CreatedAt    int64 `json:"created_at"`
+}
+
+type Client struct {
+	Username     string
+	Password     string
+	Host         string
+	InsecureSsl  bool
+	Token        AccessToken
+	ClientId     string
+	ClientSecret string
+	ApiKey       string
+	Scope        string
+	// MaxRetries is how many times a transient failure is retried, and
+	// RetryMaxWait caps the delay between two attempts.
+	MaxRetries   int
+	RetryMaxWait time.Duration
+	// PageSize is the number of items requested per page from list
+	// endpoints.
+	PageSize int
+	// GrantType is the OAuth grant used to obtain and renew Token. It is
+	// empty when authenticating with an API key or a pre-issued token,
+	// which cannot be renewed.
+	GrantType string
+
+	Systems        *SystemsService
+	SystemTriggers *SystemTriggersService
+	Zones          *ZonesService
+	Drivers        *DriversService
+	Modules        *ModulesService
+	Repositories   *RepositoriesService
+	Metadata       *MetadataService
+	Settings       *SettingsService
+	Triggers       *TriggersService
+
+	httpClient *http.Client
+
+	// tokenMu guards Token and tokenExpiry, which are replaced whenever
+	// the access token is refreshed.
+	tokenMu     sync.Mutex
+	tokenExpiry time.Time
+}
+
+// service is embedded by every resource service to reach the client.
+type service struct {
+	client *Client
+}
+
+func NewBasicAuthClient(ctx context.Context, username string, password string, host string, clientId string, clientSecret string, scope string, tlsOptions TLSOptions) (*Client, error) {
+	client, err := newClient(host, tlsOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	client.Username = username
+	client.Password = password
+	client.ClientId = clientId
+	client.ClientSecret = clientSecret
+	client.Scope = scope
+	client.GrantType = GrantTypePassword
+
+	if err := client.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// NewClientCredentialsClient returns a client authenticating as the OAuth
+// application itself, without a user.
+func NewClientCredentialsClient(ctx context.Context, host string, clientId string, clientSecret string, scope string, tlsOptions TLSOptions) (*Client, error) {
+	client, err := newClient(host, tlsOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	client.ClientId = clientId
+	client.ClientSecret = clientSecret
+	client.Scope = scope
+	client.GrantType = GrantTypeClientCredentials
+
+	if err := client.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// NewApiKeyClient returns a client authenticating every request with a
+// PlaceOS API key instead of an OAuth token.
+func NewApiKeyClient(host string, apiKey string, tlsOptions TLSOptions) (*Client, error) {
+	client, err := newClient(host, tlsOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	client.ApiKey = apiKey
+
+	return client, nil
+}
+
+// NewTokenClient returns a client sending a bearer token issued elsewhere.
+// The token is used as is and never refreshed.
+func NewTokenClient(host string, token string, tlsOptions TLSOptions) (*Client, error) {
+	client, err := newClient(host, tlsOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	client.Token = AccessToken{AccessToken: token, TokenType: "Bearer"}
+
+	return client, nil
+}
+
+// newClient returns an unauthenticated client with its services wired up.
+func newClient(host string, tlsOptions TLSOptions) (*Client, error) {
+	httpClient, err := newHTTPClient(tlsOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &Client{
+		Host:         host,
+		InsecureSsl:  tlsOptions.InsecureSsl,
+		MaxRetries:   DefaultMaxRetries,
+		RetryMaxWait: DefaultRetryMaxWait,
+		PageSize:     DefaultPageSize,
+		httpClient:   httpClient,
+	}
+
+	client.Systems = &SystemsService{client}
+	client.SystemTriggers = &SystemTriggersService{client}
+	client.Zones = &ZonesService{client}
+	client.Drivers = &DriversService{client}
+	client.Modules = &ModulesService{client}
+	client.Repositories = &RepositoriesService{client}
+	client.Metadata = &MetadataService{client}
+	client.Settings = &SettingsService{client}
+	client.Triggers = &TriggersService{client}
+
+	return client, nil
+}
+
+// newHTTPClient builds the single http.Client shared by every API call so
+// connections are pooled across the whole plan instead of per request.
+func newHTTPClient(tlsOptions TLSOptions) (*http.Client, error) {
+	tlsConfig, err := tlsOptions.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.TLSClientConfig = tlsConfig
+
+	// no overall timeout here, every request is bounded by its context
+	return &http.Client{Transport: tr}, nil
+}
+
+// Do sends a request to the engine API at path (relative to Host). body,
+// when not nil, is encoded as JSON; out, when not nil, receives the decoded
+// JSON response. It is exported for endpoints the services do not cover.
+func (client *Client) Do(ctx context.Context, method string, path string, body interface{}, out interface{}) error {
+	r, err := client.do(ctx, method, path, body)
+	if err != nil {
+		return err
+	}
+
+	if out == nil || len(r.Body) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(r.Body, out)
+}
+
+// do sends the request and returns the successful response, with its
+// headers, for callers that need more than the decoded body.
+func (client *Client) do(ctx context.Context, method string, path string, body interface{}) (*apiResponse, error) {
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	token, err := client.accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := client.sendWithRetry(ctx, method, path, payload, token)
+	if err != nil {
+		return nil, err
+	}
+
+	// the token may have been revoked or expired early, authenticate again
+	// and give the request one more chance
+	if r.StatusCode == http.StatusUnauthorized && client.GrantType != "" {
+		token, err = client.reauthorize(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+
+		r, err = client.sendWithRetry(ctx, method, path, payload, token)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if r.StatusCode < 200 || r.StatusCode > 299 {
+		return nil, newAPIError(method, path, r.StatusCode, r.Body)
+	}
+
+	return r, nil
+}
+
+// apiResponse is a fully read response from the engine.
+type apiResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// send performs a single authenticated round trip.
+func (client *Client) send(ctx context.Context, method string, path string, payload []byte, token string) (*apiResponse, error) {
+	var reqBody io.Reader
+	if payload != nil {
+		reqBody = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, client.Host+path, reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	if client.ApiKey != "" {
+		req.Header.Add("X-API-Key", client.ApiKey)
+	} else {
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
+	}
+	req.Header.Add("Accept", "application/json")
+	if payload != nil {
+		req.Header.Add("Content-Type", "application/json")
+	}
+
+	r, err := client.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+
+	w, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	tflog.Debug(ctx, "engine API request", map[string]interface{}{
+		"method": method,
+		"path":   path,
+		"status": r.StatusCode,
+	})
+
+	return &apiResponse{StatusCode: r.StatusCode, Header: r.Header, Body: w}, nil
+}
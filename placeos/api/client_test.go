@@ -0,0 +1,414 @@
+package api_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/api"
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/internal/fakeengine"
+)
+
+func newTestClient(t *testing.T, engine *fakeengine.Server) *api.Client {
+	t.Helper()
+
+	client, err := api.NewBasicAuthClient(context.Background(), fakeengine.Username, fakeengine.Password, engine.URL, fakeengine.ClientId, fakeengine.ClientSecret, "public", api.TLSOptions{})
+	if err != nil {
+		t.Fatalf("creating client: %s", err)
+	}
+	client.RetryMaxWait = 10 * time.Millisecond
+
+	return client
+}
+
+func countRequests(engine *fakeengine.Server, request string) int {
+	count := 0
+	for _, r := range engine.Requests() {
+		if r == request {
+			count++
+		}
+	}
+
+	return count
+}
+
+func TestNewBasicAuthClientRejectsBadCredentials(t *testing.T) {
+	engine := fakeengine.New()
+	defer engine.Close()
+
+	_, err := api.NewBasicAuthClient(context.Background(), fakeengine.Username, "wrong", engine.URL, fakeengine.ClientId, fakeengine.ClientSecret, "public", api.TLSOptions{})
+	if !api.IsStatus(err, http.StatusUnauthorized) {
+		t.Fatalf("expected a 401 APIError, got %v", err)
+	}
+}
+
+func TestClientCredentialsClient(t *testing.T) {
+	engine := fakeengine.New()
+	defer engine.Close()
+
+	client, err := api.NewClientCredentialsClient(context.Background(), engine.URL, fakeengine.ClientId, fakeengine.ClientSecret, "admin", api.TLSOptions{})
+	if err != nil {
+		t.Fatalf("creating client: %s", err)
+	}
+
+	if _, err := client.Zones.Create(context.Background(), &api.Zone{Name: "Building"}); err != nil {
+		t.Fatalf("creating zone: %s", err)
+	}
+}
+
+func TestApiKeyClient(t *testing.T) {
+	engine := fakeengine.New()
+	defer engine.Close()
+
+	client, err := api.NewApiKeyClient(engine.URL, fakeengine.ApiKey, api.TLSOptions{})
+	if err != nil {
+		t.Fatalf("creating client: %s", err)
+	}
+
+	if _, err := client.Zones.Create(context.Background(), &api.Zone{Name: "Building"}); err != nil {
+		t.Fatalf("creating zone: %s", err)
+	}
+	if countRequests(engine, "POST /auth/oauth/token") != 0 {
+		t.Fatalf("api key client should not request tokens")
+	}
+}
+
+func TestClientReauthorizesOnUnauthorized(t *testing.T) {
+	engine := fakeengine.New()
+	defer engine.Close()
+	client := newTestClient(t, engine)
+
+	id := engine.Seed("zones", map[string]interface{}{"name": "Level 1"})
+	engine.RevokeTokens()
+
+	zone, err := client.Zones.Get(context.Background(), id)
+	if err != nil {
+		t.Fatalf("getting zone: %s", err)
+	}
+	if zone.Name != "Level 1" {
+		t.Fatalf("expected zone Level 1, got %q", zone.Name)
+	}
+	if n := countRequests(engine, "POST /auth/oauth/token"); n != 2 {
+		t.Fatalf("expected 2 token requests, got %d", n)
+	}
+}
+
+func TestClientRefreshesExpiringToken(t *testing.T) {
+	engine := fakeengine.New()
+	defer engine.Close()
+	engine.TokenLifetime = 30 * time.Second
+	client := newTestClient(t, engine)
+
+	expiring := client.Token.AccessToken
+	if _, err := client.Repositories.List(context.Background()); err != nil {
+		t.Fatalf("listing repositories: %s", err)
+	}
+	if client.Token.AccessToken == expiring {
+		t.Fatalf("expected the access token to be refreshed")
+	}
+}
+
+func TestAPIErrorCarriesValidationFailures(t *testing.T) {
+	engine := fakeengine.New()
+	defer engine.Close()
+	client := newTestClient(t, engine)
+
+	_, err := client.Systems.Create(context.Background(), &api.System{})
+
+	apiError, ok := err.(*api.APIError)
+	if !ok {
+		t.Fatalf("expected an APIError, got %v", err)
+	}
+	if apiError.StatusCode != http.StatusUnprocessableEntity || apiError.Method != http.MethodPost || apiError.Path != "/api/engine/v2/systems" {
+		t.Fatalf("unexpected error %s", apiError)
+	}
+	if len(apiError.Failures) != 1 || apiError.Failures[0].Field != "name" {
+		t.Fatalf("expected a failure on name, got %+v", apiError.Failures)
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	engine := fakeengine.New()
+	defer engine.Close()
+	client := newTestClient(t, engine)
+
+	_, err := client.Drivers.Get(context.Background(), "driver-missing")
+	if !api.IsNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
+
+func TestClientRetriesIdempotentRequests(t *testing.T) {
+	engine := fakeengine.New()
+	defer engine.Close()
+	client := newTestClient(t, engine)
+
+	id := engine.Seed("modules", map[string]interface{}{"driver_id": "driver-1"})
+	engine.Fail(http.MethodGet, "/api/engine/v2/modules/", 2, http.StatusServiceUnavailable, `{"error":"rebalancing"}`)
+
+	if _, err := client.Modules.Get(context.Background(), id); err != nil {
+		t.Fatalf("getting module: %s", err)
+	}
+	if n := countRequests(engine, "GET /api/engine/v2/modules/"+id); n != 3 {
+		t.Fatalf("expected 3 attempts, got %d", n)
+	}
+}
+
+func TestClientDoesNotRetryCreates(t *testing.T) {
+	engine := fakeengine.New()
+	defer engine.Close()
+	client := newTestClient(t, engine)
+
+	engine.Fail(http.MethodPost, "/api/engine/v2/zones", 1, http.StatusServiceUnavailable, `{"error":"rebalancing"}`)
+
+	_, err := client.Zones.Create(context.Background(), &api.Zone{Name: "Building"})
+	if !api.IsStatus(err, http.StatusServiceUnavailable) {
+		t.Fatalf("expected a 503, got %v", err)
+	}
+	if n := countRequests(engine, "POST /api/engine/v2/zones"); n != 1 {
+		t.Fatalf("expected a single attempt, got %d", n)
+	}
+}
+
+func TestClientRetriesConnectionResets(t *testing.T) {
+	tests := map[string]struct {
+		method   string
+		requests int
+	}{
+		// the engine may have created the zone before the reset
+		"create": {method: http.MethodPost, requests: 1},
+		"read":   {method: http.MethodGet, requests: 1 + api.DefaultMaxRetries},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				conn, _, err := w.(http.Hijacker).Hijack()
+				if err != nil {
+					t.Errorf("hijacking connection: %s", err)
+					return
+				}
+				conn.(*net.TCPConn).SetLinger(0)
+				conn.Close()
+			}))
+			defer server.Close()
+
+			client, err := api.NewApiKeyClient(server.URL, fakeengine.ApiKey, api.TLSOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			client.RetryMaxWait = 10 * time.Millisecond
+
+			if err := client.Do(context.Background(), test.method, "/api/engine/v2/zones", nil, nil); err == nil {
+				t.Fatal("expected the connection reset to be reported")
+			}
+			if n := int(requests.Load()); n != test.requests {
+				t.Fatalf("expected %d requests, got %d", test.requests, n)
+			}
+		})
+	}
+}
+
+func TestClientStopsRetryingWhenContextIsDone(t *testing.T) {
+	engine := fakeengine.New()
+	defer engine.Close()
+	client := newTestClient(t, engine)
+	client.RetryMaxWait = time.Minute
+
+	engine.Fail(http.MethodGet, "/api/engine/v2/zones/", 10, http.StatusBadGateway, "")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err := client.Zones.Get(ctx, "zone-1")
+	if err == nil || !strings.Contains(err.Error(), context.DeadlineExceeded.Error()) {
+		t.Fatalf("expected the context deadline, got %v", err)
+	}
+}
+
+func TestLatestCommit(t *testing.T) {
+	engine := fakeengine.New()
+	defer engine.Close()
+	client := newTestClient(t, engine)
+
+	id := engine.Seed("repositories", map[string]interface{}{"name": "drivers", "uri": "https://github.com/placeos/drivers"})
+
+	commit, err := client.Repositories.LatestCommit(context.Background(), id, "drivers/place/staff_api.cr")
+	if err != nil {
+		t.Fatalf("getting latest commit: %s", err)
+	}
+	if commit != fakeengine.CommitHash {
+		t.Fatalf("expected commit %s, got %s", fakeengine.CommitHash, commit)
+	}
+}
+
+func TestSystemModules(t *testing.T) {
+	engine := fakeengine.New()
+	defer engine.Close()
+	client := newTestClient(t, engine)
+	ctx := context.Background()
+
+	first := engine.Seed("modules", map[string]interface{}{"driver_id": "driver-1"})
+	second := engine.Seed("modules", map[string]interface{}{"driver_id": "driver-1"})
+	id := engine.Seed("systems", map[string]interface{}{"name": "Meeting room", "modules": []string{first}})
+
+	system, err := client.Systems.AddModule(ctx, id, second)
+	if err != nil {
+		t.Fatalf("adding module: %s", err)
+	}
+	if strings.Join(system.Modules, ",") != first+","+second {
+		t.Fatalf("expected modules %s,%s, got %v", first, second, system.Modules)
+	}
+
+	system, err = client.Systems.SetModules(ctx, id, system.Version, []string{second, first})
+	if err != nil {
+		t.Fatalf("reordering modules: %s", err)
+	}
+	if strings.Join(system.Modules, ",") != second+","+first {
+		t.Fatalf("expected modules %s,%s, got %v", second, first, system.Modules)
+	}
+
+	// a module patched out of the system is kept by the engine
+	system, err = client.Systems.SetModules(ctx, id, system.Version, []string{second})
+	if err != nil {
+		t.Fatalf("removing module: %s", err)
+	}
+	if strings.Join(system.Modules, ",") != second {
+		t.Fatalf("expected modules %s, got %v", second, system.Modules)
+	}
+	if _, err := client.Modules.Get(ctx, first); err != nil {
+		t.Fatalf("expected the removed module to be kept, got %v", err)
+	}
+}
+
+func TestZonesListReadsAllPages(t *testing.T) {
+	engine := fakeengine.New()
+	defer engine.Close()
+	client := newTestClient(t, engine)
+
+	for i := 0; i < 150; i++ {
+		engine.Seed("zones", map[string]interface{}{"name": fmt.Sprintf("Level %d", i), "tags": []string{"level"}})
+	}
+	engine.Seed("zones", map[string]interface{}{"name": "Building 1", "tags": []string{"building"}})
+
+	zones, err := client.Zones.List(context.Background(), api.ZoneListOptions{Tags: []string{"level"}})
+	if err != nil {
+		t.Fatalf("listing zones: %s", err)
+	}
+	if len(zones) != 150 {
+		t.Fatalf("expected 150 levels, got %d", len(zones))
+	}
+	if requests := countRequests(engine, "GET /api/engine/v2/zones"); requests != 2 {
+		t.Fatalf("expected 2 pages to be read, got %d requests", requests)
+	}
+}
+
+func TestRepositoriesListFollowsPageLinks(t *testing.T) {
+	engine := fakeengine.New()
+	defer engine.Close()
+	client := newTestClient(t, engine)
+	client.PageSize = 10
+
+	for i := 0; i < 25; i++ {
+		engine.Seed("repositories", map[string]interface{}{"name": fmt.Sprintf("Repository %d", i)})
+	}
+
+	repositories, err := client.Repositories.List(context.Background())
+	if err != nil {
+		t.Fatalf("listing repositories: %s", err)
+	}
+	if len(repositories) != 25 {
+		t.Fatalf("expected 25 repositories, got %d", len(repositories))
+	}
+	if requests := countRequests(engine, "GET /api/engine/v2/repositories"); requests != 3 {
+		t.Fatalf("expected 3 pages to be read, got %d requests", requests)
+	}
+}
+
+func TestListPagesByOffsetWithoutLinks(t *testing.T) {
+	engine := fakeengine.New()
+	defer engine.Close()
+	engine.PageLinks = false
+	client := newTestClient(t, engine)
+	client.PageSize = 10
+
+	// a full last page must not be followed by an empty request
+	for i := 0; i < 20; i++ {
+		engine.Seed("systems", map[string]interface{}{"name": fmt.Sprintf("Room %d", i)})
+	}
+
+	systems, err := client.Systems.List(context.Background(), api.SystemListOptions{})
+	if err != nil {
+		t.Fatalf("listing systems: %s", err)
+	}
+	seen := map[string]bool{}
+	for _, system := range systems {
+		seen[system.Id] = true
+	}
+	if len(systems) != 20 || len(seen) != 20 {
+		t.Fatalf("expected 20 distinct systems, got %d (%d distinct)", len(systems), len(seen))
+	}
+	if requests := countRequests(engine, "GET /api/engine/v2/systems"); requests != 2 {
+		t.Fatalf("expected 2 pages to be read, got %d requests", requests)
+	}
+}
+
+func TestListStopsWhenPagingIsIgnored(t *testing.T) {
+	tests := map[string]struct {
+		headers  func(w http.ResponseWriter, r *http.Request)
+		requests int
+	}{
+		// a full page whatever the offset and no paging headers, the second
+		// page brings nothing new
+		"offset ignored": {
+			headers:  func(w http.ResponseWriter, r *http.Request) {},
+			requests: 2,
+		},
+		"link to itself": {
+			headers: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, r.URL.RequestURI()))
+			},
+			requests: 1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				test.headers(w, r)
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, `[{"id":"repo-1","name":"Drivers"},{"id":"repo-2","name":"Interfaces"}]`)
+			}))
+			defer server.Close()
+
+			client, err := api.NewApiKeyClient(server.URL, fakeengine.ApiKey, api.TLSOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			client.PageSize = 2
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			repositories, err := client.Repositories.List(ctx)
+			if err != nil {
+				t.Fatalf("listing repositories: %s", err)
+			}
+			if len(repositories) != 2 {
+				t.Fatalf("expected 2 repositories, got %d", len(repositories))
+			}
+			if n := int(requests.Load()); n != test.requests {
+				t.Fatalf("expected %d requests, got %d", test.requests, n)
+			}
+		})
+	}
+}
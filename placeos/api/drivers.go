@@ -0,0 +1,94 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Role values
+// 99 : Logic
+// 2  : service
+// 3  : websocket
+// 1  : device
+type Driver struct {
+	CreatedAt        int64  `json:"created_at"`
+	UpdatedAt        int64  `json:"updated_at"`
+	Id               string `json:"id"`
+	Name             string `json:"name"`
+	Description      string `json:"description"`
+	FileName         string `json:"file_name"`
+	DefaultUri       string `json:"default_uri"`
+	Commit           string `json:"commit"`
+	Role             int    `json:"role"`
+	ModuleName       string `json:"module_name"`
+	RepositoryId     string `json:"repository_id"`
+	IgnoredConnected bool   `json:"ignore_connected"`
+}
+
+// DriverListOptions filters the drivers returned by List, zero fields do
+// not filter.
+type DriverListOptions struct {
+	// Q is a search query over the driver fields.
+	Q            string
+	Role         int
+	RepositoryId string
+}
+
+func (o DriverListOptions) query() url.Values {
+	query := url.Values{}
+	if o.Q != "" {
+		query.Set("q", o.Q)
+	}
+	if o.Role != 0 {
+		query.Set("role", strconv.Itoa(o.Role))
+	}
+	if o.RepositoryId != "" {
+		query.Set("repository_id", o.RepositoryId)
+	}
+
+	return query
+}
+
+// DriversService manages drivers, /api/engine/v2/drivers. Creating or
+// updating a driver compiles it, which can take several minutes.
+type DriversService service
+
+// List returns every driver matching options, reading all pages.
+func (s *DriversService) List(ctx context.Context, options DriverListOptions) ([]Driver, error) {
+	return listAll[Driver](ctx, s.client, "/api/engine/v2/drivers", options.query())
+}
+
+func (s *DriversService) Get(ctx context.Context, id string) (*Driver, error) {
+	var driver Driver
+	if err := s.client.Do(ctx, http.MethodGet, fmt.Sprintf("/api/engine/v2/drivers/%s", id), nil, &driver); err != nil {
+		return nil, err
+	}
+
+	return &driver, nil
+}
+
+func (s *DriversService) Create(ctx context.Context, driver *Driver) (*Driver, error) {
+	var created Driver
+	if err := s.client.Do(ctx, http.MethodPost, "/api/engine/v2/drivers", driver, &created); err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// Update patches the driver identified by driver.Id.
+func (s *DriversService) Update(ctx context.Context, driver *Driver) (*Driver, error) {
+	var updated Driver
+	if err := s.client.Do(ctx, http.MethodPatch, fmt.Sprintf("/api/engine/v2/drivers/%s", driver.Id), driver, &updated); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+func (s *DriversService) Delete(ctx context.Context, id string) error {
+	return s.client.Do(ctx, http.MethodDelete, fmt.Sprintf("/api/engine/v2/drivers/%s", id), nil, nil)
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// APIError is returned by the client whenever the engine answers with a
+// non-2xx status.
+type APIError struct {
+	StatusCode int
+	Method     string
+	Path       string
+	Message    string
+	Failures   []FieldFailure
+	Body       string
+}
+
+// FieldFailure is a single validation message returned by the engine for
+// a model attribute, usually alongside a 422.
+type FieldFailure struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// errorPayload covers the error bodies rendered by the engine API.
+type errorPayload struct {
+	Error    string              `json:"error"`
+	Message  string              `json:"message"`
+	Failures []FieldFailure      `json:"failures"`
+	Errors   map[string][]string `json:"errors"`
+}
+
+func newAPIError(method string, path string, statusCode int, body []byte) *APIError {
+	apiError := &APIError{
+		StatusCode: statusCode,
+		Method:     method,
+		Path:       path,
+		Body:       string(body),
+	}
+
+	var payload errorPayload
+	if err := json.Unmarshal(body, &payload); err == nil {
+		apiError.Message = payload.Error
+		if apiError.Message == "" {
+			apiError.Message = payload.Message
+		}
+		apiError.Failures = payload.Failures
+		for field, reasons := range payload.Errors {
+			for _, reason := range reasons {
+				apiError.Failures = append(apiError.Failures, FieldFailure{Field: field, Reason: reason})
+			}
+		}
+	}
+
+	return apiError
+}
+
+func (e *APIError) Error() string {
+	message := e.Message
+	if message == "" && len(e.Failures) == 0 {
+		message = strings.TrimSpace(e.Body)
+	}
+	if message == "" {
+		message = http.StatusText(e.StatusCode)
+	}
+
+	text := fmt.Sprintf("%s %s: %d %s", e.Method, e.Path, e.StatusCode, message)
+	for _, failure := range e.Failures {
+		text += fmt.Sprintf("; %s %s", failure.Field, failure.Reason)
+	}
+
+	return text
+}
+
+// IsStatus reports whether err is an APIError with the given status code.
+func IsStatus(err error, statusCode int) bool {
+	var apiError *APIError
+	return errors.As(err, &apiError) && apiError.StatusCode == statusCode
+}
+
+// IsNotFound reports whether err is the engine saying the object is gone.
+func IsNotFound(err error) bool {
+	return IsStatus(err, http.StatusNotFound)
+}
+
+// IsConflict reports whether err is the engine rejecting an update made
+// against a stale version.
+func IsConflict(err error) bool {
+	return IsStatus(err, http.StatusConflict)
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DefaultPageSize is the number of items requested per page from list
+// endpoints by new clients, it can be overridden on the Client afterwards.
+const DefaultPageSize = 100
+
+// listAll reads every page of a list endpoint. It follows the Link rel=next
+// header of each page and, when the engine sends none, moves offset forward
+// until X-Total-Count items were read or a page comes back short. Reading
+// stops early on a link back to the same page or a page with nothing new,
+// so an engine ignoring the paging parameters cannot loop forever. query
+// holds the filters of the request.
+func listAll[T any](ctx context.Context, client *Client, path string, query url.Values) ([]T, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+	pageSize := client.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	query.Set("limit", strconv.Itoa(pageSize))
+	query.Set("offset", "0")
+
+	items := []T{}
+	seen := map[string]bool{}
+	offset := 0
+	for next := path + "?" + query.Encode(); next != ""; {
+		current := next
+		r, err := client.do(ctx, http.MethodGet, current, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page []json.RawMessage
+		if len(r.Body) > 0 {
+			if err := json.Unmarshal(r.Body, &page); err != nil {
+				return nil, err
+			}
+		}
+		offset += len(page)
+
+		// items already read are dropped, they show up again when the
+		// engine ignores offset or the list shifts between two pages
+		added := 0
+		for _, raw := range page {
+			if seen[string(raw)] {
+				continue
+			}
+			seen[string(raw)] = true
+
+			var item T
+			if err := json.Unmarshal(raw, &item); err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+			added++
+		}
+
+		next = ""
+		switch link, total := nextLink(r.Header), r.Header.Get("X-Total-Count"); {
+		case added == 0:
+		case link != "":
+			next, err = resolveLink(current, link)
+			if err != nil {
+				return nil, err
+			}
+			if next == current {
+				next = ""
+			}
+		case total != "":
+			if count, err := strconv.Atoi(total); err == nil && offset < count {
+				query.Set("offset", strconv.Itoa(offset))
+				next = path + "?" + query.Encode()
+			}
+		case len(page) == pageSize:
+			query.Set("offset", strconv.Itoa(offset))
+			next = path + "?" + query.Encode()
+		}
+	}
+
+	return items, nil
+}
+
+// nextLink returns the target of the rel="next" entry of the Link headers,
+// or "" on the last page.
+func nextLink(header http.Header) string {
+	for _, value := range header.Values("Link") {
+		for _, link := range strings.Split(value, ",") {
+			target, params, _ := strings.Cut(link, ";")
+			for _, param := range strings.Split(params, ";") {
+				name, rel, _ := strings.Cut(strings.TrimSpace(param), "=")
+				if strings.EqualFold(name, "rel") && slices.Contains(strings.Fields(strings.Trim(rel, `"`)), "next") {
+					return strings.Trim(strings.TrimSpace(target), "<>")
+				}
+			}
+		}
+	}
+
+	return ""
+}
+
+// resolveLink turns a Link target, absolute or relative to the page it was
+// read from, into a path for Do. The host is dropped so that requests, and
+// their credentials, only ever go to the configured engine.
+func resolveLink(current string, link string) (string, error) {
+	base, err := url.Parse(current)
+	if err != nil {
+		return "", err
+	}
+	target, err := url.Parse(link)
+	if err != nil {
+		return "", err
+	}
+
+	return base.ResolveReference(target).RequestURI(), nil
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Metadata is a named JSON document attached to a zone, system or user.
+type Metadata struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Details     json.RawMessage `json:"details"`
+	ParentId    string          `json:"parent_id"`
+	Editors     []string        `json:"editors"`
+
+	ModifiedById string `json:"modified_by_id,omitempty"`
+	UpdatedAt    int64  `json:"updated_at,omitempty"`
+}
+
+// MetadataService manages metadata, /api/engine/v2/metadata/{parent_id}.
+type MetadataService service
+
+// Get returns the metadata called name of parentId. The engine answers
+// with the matching documents keyed by name, a missing one is reported as
+// a 404 APIError.
+func (s *MetadataService) Get(ctx context.Context, parentId string, name string) (*Metadata, error) {
+	path := fmt.Sprintf("/api/engine/v2/metadata/%s?name=%s", parentId, url.QueryEscape(name))
+
+	var metadata map[string]Metadata
+	if err := s.client.Do(ctx, http.MethodGet, path, nil, &metadata); err != nil {
+		return nil, err
+	}
+
+	found, ok := metadata[name]
+	if !ok {
+		return nil, &APIError{
+			StatusCode: http.StatusNotFound,
+			Method:     http.MethodGet,
+			Path:       path,
+			Message:    fmt.Sprintf("metadata %s not found", name),
+		}
+	}
+
+	return &found, nil
+}
+
+// Put creates or replaces the metadata called metadata.Name of parentId.
+func (s *MetadataService) Put(ctx context.Context, parentId string, metadata *Metadata) (*Metadata, error) {
+	var saved Metadata
+	if err := s.client.Do(ctx, http.MethodPut, fmt.Sprintf("/api/engine/v2/metadata/%s", parentId), metadata, &saved); err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
+
+func (s *MetadataService) Delete(ctx context.Context, parentId string, name string) error {
+	return s.client.Do(ctx, http.MethodDelete, fmt.Sprintf("/api/engine/v2/metadata/%s?name=%s", parentId, url.QueryEscape(name)), nil, nil)
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+type Module struct {
+	CreatedAt       int64  `json:"created_at"`
+	UpdatedAt       int64  `json:"updated_at"`
+	Ip              string `json:"ip"`
+	Port            int    `json:"port"`
+	Tls             bool   `json:"tls"`
+	Udp             bool   `json:"udp"`
+	Makebreak       bool   `json:"makebreak"`
+	Uri             string `json:"uri"`
+	Name            string `json:"name"`
+	CustomName      string `json:"custom_name"`
+	Role            int    `json:"role"`
+	Connected       bool   `json:"connected"`
+	Running         bool   `json:"running"`
+	Notes           string `json:"notes"`
+	IgnoreConnected bool   `json:"ignore_connected"`
+	IgnoreStartStop bool   `json:"ignore_startstop"`
+	DriverId        string `json:"driver_id"`
+	Id              string `json:"id"`
+}
+
+// ModuleUpdate holds the module attributes that can be changed after
+// creation.
+type ModuleUpdate struct {
+	Uri             string `json:"uri"`
+	Ip              string `json:"ip"`
+	Port            int    `json:"port"`
+	Tls             bool   `json:"tls"`
+	Udp             bool   `json:"udp"`
+	Makebreak       bool   `json:"makebreak"`
+	Notes           string `json:"notes"`
+	IgnoreConnected bool   `json:"ignore_connected"`
+	IgnoreStartStop bool   `json:"ignore_startstop"`
+	DriverId        string `json:"driver_id"`
+	CustomName      string `json:"custom_name"`
+}
+
+// ModuleListOptions filters the modules returned by List, zero fields do
+// not filter.
+type ModuleListOptions struct {
+	DriverId string
+	// ControlSystemId keeps the modules of this system.
+	ControlSystemId string
+	Connected       *bool
+	Running         *bool
+	// NoLogic leaves out logic modules.
+	NoLogic bool
+}
+
+func (o ModuleListOptions) query() url.Values {
+	query := url.Values{}
+	if o.DriverId != "" {
+		query.Set("driver_id", o.DriverId)
+	}
+	if o.ControlSystemId != "" {
+		query.Set("control_system_id", o.ControlSystemId)
+	}
+	if o.Connected != nil {
+		query.Set("connected", strconv.FormatBool(*o.Connected))
+	}
+	if o.Running != nil {
+		query.Set("running", strconv.FormatBool(*o.Running))
+	}
+	if o.NoLogic {
+		query.Set("no_logic", "true")
+	}
+
+	return query
+}
+
+// ModulesService manages driver instances, /api/engine/v2/modules.
+type ModulesService service
+
+// List returns every module matching options, reading all pages.
+func (s *ModulesService) List(ctx context.Context, options ModuleListOptions) ([]Module, error) {
+	return listAll[Module](ctx, s.client, "/api/engine/v2/modules", options.query())
+}
+
+func (s *ModulesService) Get(ctx context.Context, id string) (*Module, error) {
+	var module Module
+	if err := s.client.Do(ctx, http.MethodGet, fmt.Sprintf("/api/engine/v2/modules/%s", id), nil, &module); err != nil {
+		return nil, err
+	}
+
+	return &module, nil
+}
+
+func (s *ModulesService) Create(ctx context.Context, module *Module) (*Module, error) {
+	var created Module
+	if err := s.client.Do(ctx, http.MethodPost, "/api/engine/v2/modules", module, &created); err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// Update patches the module identified by id with the editable attributes.
+func (s *ModulesService) Update(ctx context.Context, id string, update *ModuleUpdate) (*Module, error) {
+	var updated Module
+	if err := s.client.Do(ctx, http.MethodPatch, fmt.Sprintf("/api/engine/v2/modules/%s", id), update, &updated); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+func (s *ModulesService) Delete(ctx context.Context, id string) error {
+	return s.client.Do(ctx, http.MethodDelete, fmt.Sprintf("/api/engine/v2/modules/%s", id), nil, nil)
+}
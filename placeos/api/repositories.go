@@ -0,0 +1,113 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type Repository struct {
+	CreatedAt   int64  `json:"created_at"`
+	UpdatedAt   int64  `json:"updated_at"`
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	FolderName  string `json:"folder_name"`
+	Uri         string `json:"uri"`
+	CommitHash  string `json:"commit_hash"`
+	Branch      string `json:"branch"`
+	Username    string `json:"username"`
+	Password    string `json:"password"`
+	RepoType    string `json:"repo_type"`
+}
+
+// RepositoryRequest is the body sent when creating or updating a
+// repository.
+type RepositoryRequest struct {
+	Name        string `json:"name"`
+	FolderName  string `json:"folder_name"`
+	Uri         string `json:"uri"`
+	RepoType    string `json:"repo_type"`
+	Description string `json:"description"`
+	Branch      string `json:"branch"`
+	Username    string `json:"username"`
+	Password    string `json:"password"`
+}
+
+type Commit struct {
+	Commit  string `json:"commit"`
+	Date    string `json:"date"`
+	Author  string `json:"author"`
+	Subject string `json:"subject"`
+}
+
+// RepositoriesService manages driver and interface repositories,
+// /api/engine/v2/repositories.
+type RepositoriesService service
+
+func (s *RepositoriesService) List(ctx context.Context) ([]Repository, error) {
+	return listAll[Repository](ctx, s.client, "/api/engine/v2/repositories", nil)
+}
+
+func (s *RepositoriesService) Get(ctx context.Context, id string) (*Repository, error) {
+	var repository Repository
+	if err := s.client.Do(ctx, http.MethodGet, fmt.Sprintf("/api/engine/v2/repositories/%s", id), nil, &repository); err != nil {
+		return nil, err
+	}
+
+	return &repository, nil
+}
+
+func (s *RepositoriesService) Create(ctx context.Context, request *RepositoryRequest) (*Repository, error) {
+	var created Repository
+	if err := s.client.Do(ctx, http.MethodPost, "/api/engine/v2/repositories", request, &created); err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// Update patches the repository identified by id.
+func (s *RepositoriesService) Update(ctx context.Context, id string, request *RepositoryRequest) (*Repository, error) {
+	var updated Repository
+	if err := s.client.Do(ctx, http.MethodPatch, fmt.Sprintf("/api/engine/v2/repositories/%s", id), request, &updated); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+func (s *RepositoriesService) Delete(ctx context.Context, id string) error {
+	return s.client.Do(ctx, http.MethodDelete, fmt.Sprintf("/api/engine/v2/repositories/%s", id), nil, nil)
+}
+
+// Commits lists the commits of a repository, newest first. When driver is
+// not empty only commits touching that driver file are returned.
+func (s *RepositoriesService) Commits(ctx context.Context, id string, driver string) ([]Commit, error) {
+	path := fmt.Sprintf("/api/engine/v2/repositories/%s/commits", id)
+	if driver != "" {
+		path += "?driver=" + url.QueryEscape(driver)
+	}
+
+	var commits []Commit
+	if err := s.client.Do(ctx, http.MethodGet, path, nil, &commits); err != nil {
+		return nil, err
+	}
+
+	return commits, nil
+}
+
+// LatestCommit returns the hash of the newest commit touching driver.
+func (s *RepositoriesService) LatestCommit(ctx context.Context, id string, driver string) (string, error) {
+	commits, err := s.Commits(ctx, id, driver)
+	if err != nil {
+		return "", err
+	}
+
+	if len(commits) == 0 {
+		return "", fmt.Errorf("no commits found for driver %s in repository %s", driver, id)
+	}
+
+	return commits[0].Commit, nil
+}
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// DefaultMaxRetries and DefaultRetryMaxWait are applied to every new
+	// client and can be overridden on the Client afterwards.
+	DefaultMaxRetries   = 3
+	DefaultRetryMaxWait = 30 * time.Second
+
+	// retryBaseWait is the delay before the first retry, doubled on every
+	// following attempt.
+	retryBaseWait = 1 * time.Second
+)
+
+// sendWithRetry calls send, retrying transient failures up to MaxRetries
+// times. Gateway errors, timeouts and connection resets are only retried
+// for idempotent methods; refused connections are retried for every method.
+func (client *Client) sendWithRetry(ctx context.Context, method string, path string, payload []byte, token string) (*apiResponse, error) {
+	for attempt := 0; ; attempt++ {
+		r, err := client.send(ctx, method, path, payload, token)
+
+		if attempt >= client.MaxRetries || ctx.Err() != nil || !shouldRetry(method, r, err) {
+			return r, err
+		}
+
+		delay := client.retryDelay(attempt, r)
+		tflog.Debug(ctx, "retrying engine API request", map[string]interface{}{
+			"method":  method,
+			"path":    path,
+			"attempt": attempt + 1,
+			"delay":   delay.String(),
+		})
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func shouldRetry(method string, r *apiResponse, err error) bool {
+	if err != nil {
+		// a refused connection never reached the engine, a reset one may
+		// have been applied already
+		if errors.Is(err, syscall.ECONNREFUSED) {
+			return true
+		}
+		if errors.Is(err, syscall.ECONNRESET) {
+			return isIdempotent(method)
+		}
+
+		var netError net.Error
+		return isIdempotent(method) && errors.As(err, &netError) && netError.Timeout()
+	}
+
+	switch r.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(method)
+	}
+
+	return false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+// retryDelay returns how long to wait before the next attempt. Retry-After
+// is honoured when the engine sends it, otherwise the delay grows
+// exponentially with jitter. Both are capped by RetryMaxWait.
+func (client *Client) retryDelay(attempt int, r *apiResponse) time.Duration {
+	maxWait := client.RetryMaxWait
+	if maxWait <= 0 {
+		maxWait = DefaultRetryMaxWait
+	}
+
+	if r != nil {
+		if wait, ok := retryAfter(r.Header.Get("Retry-After")); ok {
+			if wait > maxWait {
+				return maxWait
+			}
+			return wait
+		}
+	}
+
+	wait := retryBaseWait << uint(attempt)
+	if wait <= 0 || wait > maxWait {
+		wait = maxWait
+	}
+
+	// pick a delay between half and the full backoff so parallel requests
+	// do not retry in lockstep
+	half := wait / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// retryAfter parses a Retry-After header, given either in seconds or as an
+// HTTP date.
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+type Setting struct {
+	Id              string   `json:"id"`
+	Name            string   `json:"name"`
+	CreatedAt       int64    `json:"created_at"`
+	UpdatedAt       int64    `json:"updated_at"`
+	Version         int64    `json:"version"`
+	ParentId        string   `json:"parent_id"`
+	ParentType      string   `json:"parent_type"`
+	SettingsString  string   `json:"settings_string"`
+	EncryptionLevel int      `json:"encryption_level"`
+	Keys            []string `json:"keys"`
+}
+
+// SettingsService manages settings attached to systems, zones, drivers and
+// modules, /api/engine/v2/settings.
+type SettingsService service
+
+func (s *SettingsService) Get(ctx context.Context, id string) (*Setting, error) {
+	var setting Setting
+	if err := s.client.Do(ctx, http.MethodGet, fmt.Sprintf("/api/engine/v2/settings/%s", id), nil, &setting); err != nil {
+		return nil, err
+	}
+
+	return &setting, nil
+}
+
+func (s *SettingsService) Create(ctx context.Context, setting *Setting) (*Setting, error) {
+	var created Setting
+	if err := s.client.Do(ctx, http.MethodPost, "/api/engine/v2/settings", setting, &created); err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// Update replaces the setting identified by setting.Id.
+func (s *SettingsService) Update(ctx context.Context, setting *Setting) (*Setting, error) {
+	var updated Setting
+	if err := s.client.Do(ctx, http.MethodPut, fmt.Sprintf("/api/engine/v2/settings/%s", setting.Id), setting, &updated); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+func (s *SettingsService) Delete(ctx context.Context, id string) error {
+	return s.client.Do(ctx, http.MethodDelete, fmt.Sprintf("/api/engine/v2/settings/%s", id), nil, nil)
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// TriggerInstance binds a trigger to a system, with per-system overrides.
+type TriggerInstance struct {
+	ControlSystemId string `json:"control_system_id"`
+	TriggerId       string `json:"trigger_id"`
+	ZoneId          string `json:"zone_id"`
+	Enabled         bool   `json:"enabled"`
+	Triggered       bool   `json:"triggered"`
+	Important       bool   `json:"important"`
+	ExecEnabled     bool   `json:"exec_enabled"`
+	WebhookSecret   string `json:"webhook_secret"`
+	TriggerCount    int64  `json:"trigger_count"`
+
+	Id        string `json:"id"`
+	CreatedAt int64  `json:"created_at"`
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+// TriggerInstanceUpdate holds the trigger instance attributes that can be
+// changed after creation.
+type TriggerInstanceUpdate struct {
+	Enabled     bool `json:"enabled"`
+	Important   bool `json:"important"`
+	ExecEnabled bool `json:"exec_enabled"`
+}
+
+// SystemTriggersService manages the triggers attached to systems,
+// /api/engine/v2/systems/{id}/triggers.
+type SystemTriggersService service
+
+func (s *SystemTriggersService) List(ctx context.Context, systemId string) ([]TriggerInstance, error) {
+	return listAll[TriggerInstance](ctx, s.client, fmt.Sprintf("/api/engine/v2/systems/%s/triggers", systemId), nil)
+}
+
+func (s *SystemTriggersService) Get(ctx context.Context, systemId string, id string) (*TriggerInstance, error) {
+	var instance TriggerInstance
+	if err := s.client.Do(ctx, http.MethodGet, fmt.Sprintf("/api/engine/v2/systems/%s/triggers/%s", systemId, id), nil, &instance); err != nil {
+		return nil, err
+	}
+
+	return &instance, nil
+}
+
+func (s *SystemTriggersService) Create(ctx context.Context, systemId string, instance *TriggerInstance) (*TriggerInstance, error) {
+	var created TriggerInstance
+	if err := s.client.Do(ctx, http.MethodPost, fmt.Sprintf("/api/engine/v2/systems/%s/triggers", systemId), instance, &created); err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// Update patches the trigger instance identified by id with the editable
+// attributes.
+func (s *SystemTriggersService) Update(ctx context.Context, systemId string, id string, update *TriggerInstanceUpdate) (*TriggerInstance, error) {
+	var updated TriggerInstance
+	if err := s.client.Do(ctx, http.MethodPatch, fmt.Sprintf("/api/engine/v2/systems/%s/triggers/%s", systemId, id), update, &updated); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+func (s *SystemTriggersService) Delete(ctx context.Context, systemId string, id string) error {
+	return s.client.Do(ctx, http.MethodDelete, fmt.Sprintf("/api/engine/v2/systems/%s/triggers/%s", systemId, id), nil, nil)
+}
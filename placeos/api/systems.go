@@ -0,0 +1,150 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+type System struct {
+	Id                 string   `json:"id"`
+	Name               string   `json:"name"`
+	CreatedAt          int64    `json:"created_at"`
+	UpdatedAt          int64    `json:"updated_at"`
+	Description        string   `json:"description"`
+	Features           []string `json:"features"`
+	Email              string   `json:"email"`
+	Bookable           bool     `json:"bookable"`
+	DisplayName        string   `json:"display_name"`
+	Code               string   `json:"code"`
+	Type               string   `json:"type"`
+	Capacity           int64    `json:"capacity"`
+	MapId              string   `json:"map_id"`
+	Images             []string `json:"images"`
+	Timezone           string   `json:"timezone"`
+	SupportUrl         string   `json:"support_url"`
+	Version            int64    `json:"version"`
+	InstalledUiDevices int64    `json:"installed_ui_devices"`
+	Zones              []string `json:"zones"`
+	Modules            []string `json:"modules"`
+}
+
+// SystemListOptions filters the systems returned by List, zero fields do
+// not filter.
+type SystemListOptions struct {
+	// Q is a search query over the system fields.
+	Q        string
+	ZoneId   string
+	ModuleId string
+	// Features keeps the systems having all of these features.
+	Features []string
+	// Capacity keeps the systems seating at least this many people.
+	Capacity int64
+	Bookable *bool
+}
+
+func (o SystemListOptions) query() url.Values {
+	query := url.Values{}
+	if o.Q != "" {
+		query.Set("q", o.Q)
+	}
+	if o.ZoneId != "" {
+		query.Set("zone_id", o.ZoneId)
+	}
+	if o.ModuleId != "" {
+		query.Set("module_id", o.ModuleId)
+	}
+	if len(o.Features) > 0 {
+		query.Set("features", strings.Join(o.Features, ","))
+	}
+	if o.Capacity > 0 {
+		query.Set("capacity", strconv.FormatInt(o.Capacity, 10))
+	}
+	if o.Bookable != nil {
+		query.Set("bookable", strconv.FormatBool(*o.Bookable))
+	}
+
+	return query
+}
+
+// SystemsService manages control systems, /api/engine/v2/systems.
+type SystemsService service
+
+// List returns every system matching options, reading all pages.
+func (s *SystemsService) List(ctx context.Context, options SystemListOptions) ([]System, error) {
+	return listAll[System](ctx, s.client, "/api/engine/v2/systems", options.query())
+}
+
+func (s *SystemsService) Get(ctx context.Context, id string) (*System, error) {
+	var system System
+	if err := s.client.Do(ctx, http.MethodGet, fmt.Sprintf("/api/engine/v2/systems/%s", id), nil, &system); err != nil {
+		return nil, err
+	}
+
+	return &system, nil
+}
+
+func (s *SystemsService) Create(ctx context.Context, system *System) (*System, error) {
+	var created System
+	if err := s.client.Do(ctx, http.MethodPost, "/api/engine/v2/systems", system, &created); err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// Update replaces the system identified by system.Id.
+func (s *SystemsService) Update(ctx context.Context, system *System) (*System, error) {
+	var updated System
+	if err := s.client.Do(ctx, http.MethodPut, fmt.Sprintf("/api/engine/v2/systems/%s", system.Id), system, &updated); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+func (s *SystemsService) Delete(ctx context.Context, id string) error {
+	return s.client.Do(ctx, http.MethodDelete, fmt.Sprintf("/api/engine/v2/systems/%s", id), nil, nil)
+}
+
+// systemModules is the partial update used to reorder the modules of a
+// system without replacing its other attributes.
+type systemModules struct {
+	Modules []string `json:"modules"`
+	Version int64    `json:"version"`
+}
+
+// AddModule appends the module to the system, /systems/{id}/module/{module_id}.
+func (s *SystemsService) AddModule(ctx context.Context, id string, moduleId string) (*System, error) {
+	var updated System
+	if err := s.client.Do(ctx, http.MethodPut, fmt.Sprintf("/api/engine/v2/systems/%s/module/%s", id, moduleId), nil, &updated); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// SetModules patches the module order of the system, leaving the other
+// attributes untouched. version is the system version the order applies to.
+func (s *SystemsService) SetModules(ctx context.Context, id string, version int64, modules []string) (*System, error) {
+	var updated System
+	body := &systemModules{Modules: modules, Version: version}
+	if err := s.client.Do(ctx, http.MethodPatch, fmt.Sprintf("/api/engine/v2/systems/%s", id), body, &updated); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// Start starts every module of the system, /systems/{id}/start.
+func (s *SystemsService) Start(ctx context.Context, id string) error {
+	return s.client.Do(ctx, http.MethodPost, fmt.Sprintf("/api/engine/v2/systems/%s/start", id), nil, nil)
+}
+
+// Stop stops every module of the system, /systems/{id}/stop.
+func (s *SystemsService) Stop(ctx context.Context, id string) error {
+	return s.client.Do(ctx, http.MethodPost, fmt.Sprintf("/api/engine/v2/systems/%s/stop", id), nil, nil)
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+// TLSOptions configures how the client verifies the engine and, optionally,
+// authenticates itself with a client certificate. Certificate values accept
+// either a path to a PEM file or the PEM content itself.
+type TLSOptions struct {
+	InsecureSsl       bool
+	CACertificate     string
+	ClientCertificate string
+	ClientKey         string
+}
+
+func (options TLSOptions) tlsConfig() (*tls.Config, error) {
+	config := &tls.Config{InsecureSkipVerify: options.InsecureSsl}
+
+	if options.CACertificate != "" {
+		caPem, err := readPem(options.CACertificate)
+		if err != nil {
+			return nil, fmt.Errorf("reading ca_certificate: %w", err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caPem) {
+			return nil, fmt.Errorf("ca_certificate does not contain any valid PEM certificates")
+		}
+		config.RootCAs = pool
+	}
+
+	if options.ClientCertificate != "" || options.ClientKey != "" {
+		if options.ClientCertificate == "" || options.ClientKey == "" {
+			return nil, fmt.Errorf("client_certificate and client_key must be set together")
+		}
+
+		certPem, err := readPem(options.ClientCertificate)
+		if err != nil {
+			return nil, fmt.Errorf("reading client_certificate: %w", err)
+		}
+		keyPem, err := readPem(options.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("reading client_key: %w", err)
+		}
+
+		certificate, err := tls.X509KeyPair(certPem, keyPem)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+
+	return config, nil
+}
+
+// readPem returns value unchanged when it already holds PEM data, otherwise
+// it treats value as a file path.
+func readPem(value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+
+	return ioutil.ReadFile(value)
+}
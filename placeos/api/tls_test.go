@@ -0,0 +1,212 @@
+package api_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/api"
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/internal/fakeengine"
+)
+
+// testCertificate is a generated certificate with its key, both PEM encoded.
+type testCertificate struct {
+	certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+	certPem     string
+	keyPem      string
+}
+
+// newTestCertificate generates a certificate from template, signed by parent
+// or self-signed when parent is nil.
+func newTestCertificate(t *testing.T, template *x509.Certificate, parent *testCertificate) *testCertificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %s", err)
+	}
+
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+	signer, signerKey := template, key
+	if parent != nil {
+		signer, signerKey = parent.certificate, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("creating certificate: %s", err)
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parsing certificate: %s", err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("encoding key: %s", err)
+	}
+
+	return &testCertificate{
+		certificate: certificate,
+		key:         key,
+		certPem:     string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		keyPem:      string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})),
+	}
+}
+
+func writeTestFile(t *testing.T, dir string, name string, content string) string {
+	t.Helper()
+
+	file := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(file, []byte(content), 0600); err != nil {
+		t.Fatalf("writing %s: %s", name, err)
+	}
+
+	return file
+}
+
+func TestClientTLSOptions(t *testing.T) {
+	ca := newTestCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "PlaceOS test CA"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil)
+	serverCert := newTestCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "engine"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca)
+	clientCert := newTestCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca)
+	otherCert := newTestCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(4),
+		Subject:      pkix.Name{CommonName: "other"},
+	}, ca)
+
+	dir := t.TempDir()
+	caFile := writeTestFile(t, dir, "ca.pem", ca.certPem)
+	clientCertFile := writeTestFile(t, dir, "client.pem", clientCert.certPem)
+	clientKeyFile := writeTestFile(t, dir, "client-key.pem", clientCert.keyPem)
+	invalidFile := writeTestFile(t, dir, "invalid.pem", "not a certificate")
+
+	serverKeyPair, err := tls.X509KeyPair([]byte(serverCert.certPem), []byte(serverCert.keyPem))
+	if err != nil {
+		t.Fatalf("loading server certificate: %s", err)
+	}
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.certificate)
+
+	var clientName atomic.Value
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := ""
+		if len(r.TLS.PeerCertificates) > 0 {
+			name = r.TLS.PeerCertificates[0].Subject.CommonName
+		}
+		clientName.Store(name)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[]`))
+	}))
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{serverKeyPair},
+		ClientAuth:   tls.VerifyClientCertIfGiven,
+		ClientCAs:    clientCAs,
+	}
+	// the unknown ca case fails the handshake on purpose
+	server.Config.ErrorLog = log.New(ioutil.Discard, "", 0)
+	server.StartTLS()
+	defer server.Close()
+
+	tests := map[string]struct {
+		options    api.TLSOptions
+		clientErr  string
+		requestErr bool
+		clientName string
+	}{
+		"unknown ca":  {options: api.TLSOptions{}, requestErr: true},
+		"insecure":    {options: api.TLSOptions{InsecureSsl: true}},
+		"ca file":     {options: api.TLSOptions{CACertificate: caFile}},
+		"inline ca":   {options: api.TLSOptions{CACertificate: ca.certPem}},
+		"missing ca":  {options: api.TLSOptions{CACertificate: filepath.Join(dir, "missing.pem")}, clientErr: "reading ca_certificate"},
+		"invalid ca":  {options: api.TLSOptions{CACertificate: invalidFile}, clientErr: "does not contain any valid PEM certificates"},
+		"inline junk": {options: api.TLSOptions{CACertificate: "-----BEGIN CERTIFICATE-----\nnope\n-----END CERTIFICATE-----\n"}, clientErr: "does not contain any valid PEM certificates"},
+		"client certificate files": {
+			options:    api.TLSOptions{CACertificate: caFile, ClientCertificate: clientCertFile, ClientKey: clientKeyFile},
+			clientName: "terraform",
+		},
+		"inline client certificate": {
+			options:    api.TLSOptions{CACertificate: ca.certPem, ClientCertificate: clientCert.certPem, ClientKey: clientCert.keyPem},
+			clientName: "terraform",
+		},
+		"mismatched client key": {
+			options:   api.TLSOptions{CACertificate: caFile, ClientCertificate: clientCertFile, ClientKey: otherCert.keyPem},
+			clientErr: "loading client certificate",
+		},
+		"invalid client key": {
+			options:   api.TLSOptions{CACertificate: caFile, ClientCertificate: clientCertFile, ClientKey: invalidFile},
+			clientErr: "loading client certificate",
+		},
+		"missing client key": {
+			options:   api.TLSOptions{CACertificate: caFile, ClientCertificate: clientCertFile, ClientKey: filepath.Join(dir, "missing-key.pem")},
+			clientErr: "reading client_key",
+		},
+		"client certificate without key": {
+			options:   api.TLSOptions{CACertificate: caFile, ClientCertificate: clientCertFile},
+			clientErr: "must be set together",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client, err := api.NewApiKeyClient(server.URL, fakeengine.ApiKey, test.options)
+			if test.clientErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.clientErr) {
+					t.Fatalf("expected an error containing %q, got %v", test.clientErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("creating client: %s", err)
+			}
+			client.MaxRetries = 0
+
+			err = client.Do(context.Background(), http.MethodGet, "/api/engine/v2/zones", nil, nil)
+			if test.requestErr {
+				if err == nil {
+					t.Fatal("expected the engine certificate to be rejected")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("requesting zones: %s", err)
+			}
+			if name := clientName.Load(); name != test.clientName {
+				t.Fatalf("expected client certificate %q, got %q", test.clientName, name)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+type Trigger struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+
+	Actions          TriggerActions    `json:"actions"`
+	Conditions       TriggerConditions `json:"conditions"`
+	DebouncePeriod   int64             `json:"debounce_period"`
+	Important        bool              `json:"important"`
+	EnableWebhook    bool              `json:"enable_webhook"`
+	SupportedMethods []string          `json:"supported_methods"`
+
+	Id        string `json:"id"`
+	CreatedAt int64  `json:"created_at"`
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+// TriggerActions are run when the conditions of a trigger are met.
+type TriggerActions struct {
+	Functions []TriggerFunction `json:"functions"`
+	Mailers   []TriggerMailer   `json:"mailers"`
+}
+
+// TriggerFunction calls method on the module mod of the system, args holds
+// the named arguments as a JSON object.
+type TriggerFunction struct {
+	Mod    string          `json:"mod"`
+	Method string          `json:"method"`
+	Args   json.RawMessage `json:"args"`
+}
+
+type TriggerMailer struct {
+	Emails  []string `json:"emails"`
+	Content string   `json:"content"`
+}
+
+type TriggerConditions struct {
+	Comparisons    []TriggerComparison    `json:"comparisons"`
+	TimeDependents []TriggerTimeDependent `json:"time_dependents"`
+}
+
+type TriggerComparison struct {
+	Left     TriggerValue `json:"left"`
+	Operator string       `json:"operator"`
+	Right    TriggerValue `json:"right"`
+}
+
+// TriggerValue is a side of a comparison, either a JSON constant or the
+// status of a module.
+type TriggerValue struct {
+	Const  json.RawMessage
+	Status *TriggerStatus
+}
+
+// TriggerStatus points at a module status, keys digs into its value.
+type TriggerStatus struct {
+	Mod    string   `json:"mod"`
+	Status string   `json:"status"`
+	Keys   []string `json:"keys"`
+}
+
+func (v TriggerValue) MarshalJSON() ([]byte, error) {
+	if v.Status != nil {
+		return json.Marshal(v.Status)
+	}
+	if len(v.Const) == 0 {
+		return []byte("null"), nil
+	}
+
+	return v.Const, nil
+}
+
+func (v *TriggerValue) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		v.Status = &TriggerStatus{}
+		return json.Unmarshal(data, v.Status)
+	}
+
+	v.Const = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+// TriggerTimeDependent fires the trigger once at Time (unix seconds) for
+// the "at" type, or on the Cron schedule for the "cron" type.
+type TriggerTimeDependent struct {
+	Type     string `json:"type"`
+	Time     int64  `json:"time,omitempty"`
+	Cron     string `json:"cron,omitempty"`
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// TriggersService manages triggers, /api/engine/v2/triggers.
+type TriggersService service
+
+func (s *TriggersService) Get(ctx context.Context, id string) (*Trigger, error) {
+	var trigger Trigger
+	if err := s.client.Do(ctx, http.MethodGet, fmt.Sprintf("/api/engine/v2/triggers/%s", id), nil, &trigger); err != nil {
+		return nil, err
+	}
+
+	return &trigger, nil
+}
+
+func (s *TriggersService) Create(ctx context.Context, trigger *Trigger) (*Trigger, error) {
+	var created Trigger
+	if err := s.client.Do(ctx, http.MethodPost, "/api/engine/v2/triggers", trigger, &created); err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// Update replaces the trigger identified by trigger.Id.
+func (s *TriggersService) Update(ctx context.Context, trigger *Trigger) (*Trigger, error) {
+	var updated Trigger
+	if err := s.client.Do(ctx, http.MethodPut, fmt.Sprintf("/api/engine/v2/triggers/%s", trigger.Id), trigger, &updated); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+func (s *TriggersService) Delete(ctx context.Context, id string) error {
+	return s.client.Do(ctx, http.MethodDelete, fmt.Sprintf("/api/engine/v2/triggers/%s", id), nil, nil)
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type Zone struct {
+	Name string `json:"name"`
+
+	Description string   `json:"description"`
+	Tags        []string `json:"tags"`
+	Location    string   `json:"location"`
+	DisplayName string   `json:"display_name"`
+	Code        string   `json:"code"`
+	Type        string   `json:"type"`
+	Count       int      `json:"count"`
+	Capacity    int      `json:"capacity"`
+	MapId       string   `json:"map_id"`
+	ParentId    string   `json:"parent_id"`
+
+	Id        string `json:"id"`
+	CreatedAt int64  `json:"created_at"`
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+// ZoneListOptions filters the zones returned by List, empty fields do not
+// filter.
+type ZoneListOptions struct {
+	// Q is a search query over the zone fields.
+	Q string
+	// Tags keeps the zones carrying any of these tags.
+	Tags     []string
+	ParentId string
+}
+
+func (o ZoneListOptions) query() url.Values {
+	query := url.Values{}
+	if o.Q != "" {
+		query.Set("q", o.Q)
+	}
+	if len(o.Tags) > 0 {
+		query.Set("tags", strings.Join(o.Tags, ","))
+	}
+	if o.ParentId != "" {
+		query.Set("parent_id", o.ParentId)
+	}
+
+	return query
+}
+
+// ZonesService manages zones, /api/engine/v2/zones.
+type ZonesService service
+
+// List returns every zone matching options, reading all pages.
+func (s *ZonesService) List(ctx context.Context, options ZoneListOptions) ([]Zone, error) {
+	return listAll[Zone](ctx, s.client, "/api/engine/v2/zones", options.query())
+}
+
+func (s *ZonesService) Get(ctx context.Context, id string) (*Zone, error) {
+	var zone Zone
+	if err := s.client.Do(ctx, http.MethodGet, fmt.Sprintf("/api/engine/v2/zones/%s", id), nil, &zone); err != nil {
+		return nil, err
+	}
+
+	return &zone, nil
+}
+
+func (s *ZonesService) Create(ctx context.Context, zone *Zone) (*Zone, error) {
+	var created Zone
+	if err := s.client.Do(ctx, http.MethodPost, "/api/engine/v2/zones", zone, &created); err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// Update replaces the zone identified by zone.Id.
+func (s *ZonesService) Update(ctx context.Context, zone *Zone) (*Zone, error) {
+	var updated Zone
+	if err := s.client.Do(ctx, http.MethodPut, fmt.Sprintf("/api/engine/v2/zones/%s", zone.Id), zone, &updated); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+func (s *ZonesService) Delete(ctx context.Context, id string) error {
+	return s.client.Do(ctx, http.MethodDelete, fmt.Sprintf("/api/engine/v2/zones/%s", id), nil, nil)
+}
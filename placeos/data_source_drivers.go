@@ -0,0 +1,166 @@
+package placeos
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/api"
+)
+
+var (
+	_ datasource.DataSource              = &driversDataSource{}
+	_ datasource.DataSourceWithConfigure = &driversDataSource{}
+)
+
+type driversDataSource struct {
+	client *api.Client
+}
+
+type driversDataSourceModel struct {
+	Id           types.String           `tfsdk:"id"`
+	Q            types.String           `tfsdk:"q"`
+	Role         types.Int64            `tfsdk:"role"`
+	RepositoryId types.String           `tfsdk:"repository_id"`
+	Drivers      []driverDataSourceItem `tfsdk:"drivers"`
+}
+
+type driverDataSourceItem struct {
+	Id               types.String `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	FileName         types.String `tfsdk:"file_name"`
+	DefaultUri       types.String `tfsdk:"default_uri"`
+	ModuleName       types.String `tfsdk:"module_name"`
+	Description      types.String `tfsdk:"description"`
+	RepositoryId     types.String `tfsdk:"repository_id"`
+	Commit           types.String `tfsdk:"commit"`
+	Role             types.Int64  `tfsdk:"role"`
+	IgnoredConnected types.Bool   `tfsdk:"ignored_connected"`
+	CreatedAt        types.Int64  `tfsdk:"created_at"`
+	UpdatedAt        types.Int64  `tfsdk:"updated_at"`
+}
+
+func newDriversDataSource() datasource.DataSource {
+	return &driversDataSource{}
+}
+
+func (d *driversDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_drivers"
+}
+
+func (d *driversDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the drivers matching all the given filters.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"q": schema.StringAttribute{
+				Optional:    true,
+				Description: "Search query over the driver fields.",
+			},
+			"role": schema.Int64Attribute{
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.OneOf(1, 2, 3, 99)},
+				Description: "Only drivers of this role: 1 device, 2 service, 3 websocket or 99 logic.",
+			},
+			"repository_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only drivers built from this repository.",
+			},
+			"drivers": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed: true,
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"file_name": schema.StringAttribute{
+							Computed: true,
+						},
+						"default_uri": schema.StringAttribute{
+							Computed: true,
+						},
+						"module_name": schema.StringAttribute{
+							Computed: true,
+						},
+						"description": schema.StringAttribute{
+							Computed: true,
+						},
+						"repository_id": schema.StringAttribute{
+							Computed: true,
+						},
+						"commit": schema.StringAttribute{
+							Computed: true,
+						},
+						"role": schema.Int64Attribute{
+							Computed: true,
+						},
+						"ignored_connected": schema.BoolAttribute{
+							Computed: true,
+						},
+						"created_at": schema.Int64Attribute{
+							Computed: true,
+						},
+						"updated_at": schema.Int64Attribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *driversDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = clientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *driversDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state driversDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	drivers, err := d.client.Drivers.List(ctx, api.DriverListOptions{
+		Q:            state.Q.ValueString(),
+		Role:         int(state.Role.ValueInt64()),
+		RepositoryId: state.RepositoryId.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+		return
+	}
+
+	// always run
+	state.Id = types.StringValue(strconv.FormatInt(time.Now().Unix(), 10))
+	state.Drivers = []driverDataSourceItem{}
+	for _, driver := range drivers {
+		state.Drivers = append(state.Drivers, driverDataSourceItem{
+			Id:               types.StringValue(driver.Id),
+			Name:             types.StringValue(driver.Name),
+			FileName:         types.StringValue(driver.FileName),
+			DefaultUri:       types.StringValue(driver.DefaultUri),
+			ModuleName:       types.StringValue(driver.ModuleName),
+			Description:      types.StringValue(driver.Description),
+			RepositoryId:     types.StringValue(driver.RepositoryId),
+			Commit:           types.StringValue(driver.Commit),
+			Role:             types.Int64Value(int64(driver.Role)),
+			IgnoredConnected: types.BoolValue(driver.IgnoredConnected),
+			CreatedAt:        types.Int64Value(driver.CreatedAt),
+			UpdatedAt:        types.Int64Value(driver.UpdatedAt),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,81 @@
+package placeos
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestUnitDataSourceDrivers_basic(t *testing.T) {
+	engine := testFakeEngine(t)
+	repositoryId := engine.Seed("repositories", map[string]interface{}{"name": "Drivers"})
+	bookingsId := engine.Seed("drivers", map[string]interface{}{
+		"name":          "Bookings",
+		"module_name":   "Bookings",
+		"repository_id": repositoryId,
+		"role":          2,
+	})
+	engine.Seed("drivers", map[string]interface{}{
+		"name":          "Booking panel",
+		"module_name":   "Panel",
+		"repository_id": "repository-2",
+		"role":          1,
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeProviderConfig(engine) + fmt.Sprintf(`
+data "placeos_drivers" "all" {
+  q = "booking"
+}
+
+data "placeos_drivers" "services" {
+  role = 2
+}
+
+data "placeos_drivers" "repository" {
+  repository_id = %q
+}
+`, repositoryId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.placeos_drivers.all", "drivers.#", "2"),
+					resource.TestCheckResourceAttr("data.placeos_drivers.services", "drivers.#", "1"),
+					resource.TestCheckResourceAttr("data.placeos_drivers.services", "drivers.0.id", bookingsId),
+					resource.TestCheckResourceAttr("data.placeos_drivers.services", "drivers.0.module_name", "Bookings"),
+					resource.TestCheckResourceAttr("data.placeos_drivers.repository", "drivers.#", "1"),
+					resource.TestCheckResourceAttr("data.placeos_drivers.repository", "drivers.0.role", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceDrivers_basic(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-drivers")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDriverDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDriverConfig(name, "Bookings") + `
+data "placeos_drivers" "test" {
+  repository_id = placeos_repository.test.id
+
+  depends_on = [placeos_driver.test]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.placeos_drivers.test", "drivers.#", "1"),
+					resource.TestCheckResourceAttrPair("data.placeos_drivers.test", "drivers.0.id", "placeos_driver.test", "id"),
+				),
+			},
+		},
+	})
+}
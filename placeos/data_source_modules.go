@@ -0,0 +1,183 @@
+package placeos
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/api"
+)
+
+var (
+	_ datasource.DataSource              = &modulesDataSource{}
+	_ datasource.DataSourceWithConfigure = &modulesDataSource{}
+)
+
+type modulesDataSource struct {
+	client *api.Client
+}
+
+type modulesDataSourceModel struct {
+	Id              types.String           `tfsdk:"id"`
+	DriverId        types.String           `tfsdk:"driver_id"`
+	ControlSystemId types.String           `tfsdk:"control_system_id"`
+	Connected       types.Bool             `tfsdk:"connected"`
+	Running         types.Bool             `tfsdk:"running"`
+	NoLogic         types.Bool             `tfsdk:"no_logic"`
+	Modules         []moduleDataSourceItem `tfsdk:"modules"`
+}
+
+type moduleDataSourceItem struct {
+	Id              types.String `tfsdk:"id"`
+	CustomName      types.String `tfsdk:"custom_name"`
+	DriverId        types.String `tfsdk:"driver_id"`
+	Uri             types.String `tfsdk:"uri"`
+	Notes           types.String `tfsdk:"notes"`
+	Ip              types.String `tfsdk:"ip"`
+	Port            types.Int64  `tfsdk:"port"`
+	Makebreak       types.Bool   `tfsdk:"makebreak"`
+	IgnoreConnected types.Bool   `tfsdk:"ignore_connected"`
+	IgnoreStartStop types.Bool   `tfsdk:"ignore_starstop"`
+	Tls             types.Bool   `tfsdk:"tls"`
+	Udp             types.Bool   `tfsdk:"udp"`
+	CreatedAt       types.Int64  `tfsdk:"created_at"`
+	UpdatedAt       types.Int64  `tfsdk:"updated_at"`
+}
+
+func newModulesDataSource() datasource.DataSource {
+	return &modulesDataSource{}
+}
+
+func (d *modulesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_modules"
+}
+
+func (d *modulesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the modules matching all the given filters.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"driver_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only instances of this driver.",
+			},
+			"control_system_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only the modules of this system.",
+			},
+			"connected": schema.BoolAttribute{
+				Optional: true,
+			},
+			"running": schema.BoolAttribute{
+				Optional: true,
+			},
+			"no_logic": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Leave out logic modules.",
+			},
+			"modules": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed: true,
+						},
+						"custom_name": schema.StringAttribute{
+							Computed: true,
+						},
+						"driver_id": schema.StringAttribute{
+							Computed: true,
+						},
+						"uri": schema.StringAttribute{
+							Computed: true,
+						},
+						"notes": schema.StringAttribute{
+							Computed: true,
+						},
+						"ip": schema.StringAttribute{
+							Computed: true,
+						},
+						"port": schema.Int64Attribute{
+							Computed: true,
+						},
+						"makebreak": schema.BoolAttribute{
+							Computed: true,
+						},
+						"ignore_connected": schema.BoolAttribute{
+							Computed: true,
+						},
+						"ignore_starstop": schema.BoolAttribute{
+							Computed: true,
+						},
+						"tls": schema.BoolAttribute{
+							Computed: true,
+						},
+						"udp": schema.BoolAttribute{
+							Computed: true,
+						},
+						"created_at": schema.Int64Attribute{
+							Computed: true,
+						},
+						"updated_at": schema.Int64Attribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *modulesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = clientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *modulesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state modulesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	modules, err := d.client.Modules.List(ctx, api.ModuleListOptions{
+		DriverId:        state.DriverId.ValueString(),
+		ControlSystemId: state.ControlSystemId.ValueString(),
+		Connected:       state.Connected.ValueBoolPointer(),
+		Running:         state.Running.ValueBoolPointer(),
+		NoLogic:         state.NoLogic.ValueBool(),
+	})
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+		return
+	}
+
+	// always run
+	state.Id = types.StringValue(strconv.FormatInt(time.Now().Unix(), 10))
+	state.Modules = []moduleDataSourceItem{}
+	for _, module := range modules {
+		state.Modules = append(state.Modules, moduleDataSourceItem{
+			Id:              types.StringValue(module.Id),
+			CustomName:      types.StringValue(module.CustomName),
+			DriverId:        types.StringValue(module.DriverId),
+			Uri:             types.StringValue(module.Uri),
+			Notes:           types.StringValue(module.Notes),
+			Ip:              types.StringValue(module.Ip),
+			Port:            types.Int64Value(int64(module.Port)),
+			Makebreak:       types.BoolValue(module.Makebreak),
+			IgnoreConnected: types.BoolValue(module.IgnoreConnected),
+			IgnoreStartStop: types.BoolValue(module.IgnoreStartStop),
+			Tls:             types.BoolValue(module.Tls),
+			Udp:             types.BoolValue(module.Udp),
+			CreatedAt:       types.Int64Value(module.CreatedAt),
+			UpdatedAt:       types.Int64Value(module.UpdatedAt),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
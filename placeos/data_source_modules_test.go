@@ -0,0 +1,96 @@
+package placeos
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestUnitDataSourceModules_basic(t *testing.T) {
+	engine := testFakeEngine(t)
+	displayId := engine.Seed("modules", map[string]interface{}{
+		"driver_id":   "driver-1",
+		"custom_name": "Display",
+		"role":        1,
+		"connected":   true,
+		"running":     true,
+	})
+	engine.Seed("modules", map[string]interface{}{
+		"driver_id": "driver-1",
+		"role":      1,
+	})
+	logicId := engine.Seed("modules", map[string]interface{}{
+		"driver_id": "driver-2",
+		"role":      99,
+		"running":   true,
+	})
+	systemId := engine.Seed("systems", map[string]interface{}{
+		"name":    "Boardroom",
+		"modules": []string{displayId, logicId},
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeProviderConfig(engine) + fmt.Sprintf(`
+data "placeos_modules" "driver" {
+  driver_id = "driver-1"
+}
+
+data "placeos_modules" "disconnected" {
+  driver_id = "driver-1"
+  connected = false
+}
+
+data "placeos_modules" "system" {
+  control_system_id = %[1]q
+}
+
+data "placeos_modules" "devices" {
+  control_system_id = %[1]q
+  running           = true
+  no_logic          = true
+}
+`, systemId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.placeos_modules.driver", "modules.#", "2"),
+					resource.TestCheckResourceAttr("data.placeos_modules.disconnected", "modules.#", "1"),
+					resource.TestCheckResourceAttr("data.placeos_modules.disconnected", "modules.0.custom_name", ""),
+					resource.TestCheckResourceAttr("data.placeos_modules.system", "modules.#", "2"),
+					resource.TestCheckResourceAttr("data.placeos_modules.devices", "modules.#", "1"),
+					resource.TestCheckResourceAttr("data.placeos_modules.devices", "modules.0.id", displayId),
+					resource.TestCheckResourceAttr("data.placeos_modules.devices", "modules.0.custom_name", "Display"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceModules_basic(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-modules")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckModuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccModuleConfig(name, "first", "Bookings") + `
+data "placeos_modules" "test" {
+  driver_id = placeos_driver.first.id
+
+  depends_on = [placeos_module.test]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.placeos_modules.test", "modules.#", "1"),
+					resource.TestCheckResourceAttrPair("data.placeos_modules.test", "modules.0.id", "placeos_module.test", "id"),
+				),
+			},
+		},
+	})
+}
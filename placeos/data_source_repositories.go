@@ -5,66 +5,95 @@ import (
 	"strconv"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/api"
+)
+
+var (
+	_ datasource.DataSource              = &repositoriesDataSource{}
+	_ datasource.DataSourceWithConfigure = &repositoriesDataSource{}
 )
 
-func dataSourceRepository() *schema.Resource {
-	return &schema.Resource{
-		ReadContext: dataSourceRepositoriesRead,
-		Schema: map[string]*schema.Schema{
-			"repositories": &schema.Schema{
-				Type:     schema.TypeList,
+type repositoriesDataSource struct {
+	client *api.Client
+}
+
+type repositoriesDataSourceModel struct {
+	Id           types.String               `tfsdk:"id"`
+	Repositories []repositoryDataSourceItem `tfsdk:"repositories"`
+}
+
+type repositoryDataSourceItem struct {
+	Id          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	FolderName  types.String `tfsdk:"folder_name"`
+	Uri         types.String `tfsdk:"uri"`
+	CommitHash  types.String `tfsdk:"commit_hash"`
+	Branch      types.String `tfsdk:"branch"`
+	RepoType    types.String `tfsdk:"repo_type"`
+	Username    types.String `tfsdk:"username"`
+	Password    types.String `tfsdk:"password"`
+	CreatedAt   types.Int64  `tfsdk:"created_at"`
+	UpdatedAt   types.Int64  `tfsdk:"updated_at"`
+}
+
+func newRepositoriesDataSource() datasource.DataSource {
+	return &repositoriesDataSource{}
+}
+
+func (d *repositoriesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_repositories"
+}
+
+func (d *repositoriesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
 				Computed: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"created_at": &schema.Schema{
-							Type:     schema.TypeInt,
+			},
+			"repositories": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
 							Computed: true,
 						},
-						"updated_at": &schema.Schema{
-							Type:     schema.TypeInt,
+						"name": schema.StringAttribute{
 							Computed: true,
 						},
-						"name": &schema.Schema{
-							Type:     schema.TypeString,
+						"description": schema.StringAttribute{
 							Computed: true,
 						},
-						"description": &schema.Schema{
-							Type:     schema.TypeString,
+						"folder_name": schema.StringAttribute{
 							Computed: true,
 						},
-						"folder_name": &schema.Schema{
-							Type:     schema.TypeString,
+						"uri": schema.StringAttribute{
 							Computed: true,
 						},
-						"uri": &schema.Schema{
-							Type:     schema.TypeString,
+						"commit_hash": schema.StringAttribute{
 							Computed: true,
 						},
-						"commit_hash": &schema.Schema{
-							Type:     schema.TypeString,
+						"branch": schema.StringAttribute{
 							Computed: true,
-							Optional: true,
 						},
-						"branch": &schema.Schema{
-							Type:     schema.TypeString,
+						"repo_type": schema.StringAttribute{
 							Computed: true,
 						},
-						"repo_type": &schema.Schema{
-							Type:     schema.TypeString,
+						"username": schema.StringAttribute{
 							Computed: true,
 						},
-						"id": &schema.Schema{
-							Type:     schema.TypeString,
-							Computed: true,
+						"password": schema.StringAttribute{
+							Computed:  true,
+							Sensitive: true,
 						},
-						"username": &schema.Schema{
-							Type:     schema.TypeString,
+						"created_at": schema.Int64Attribute{
 							Computed: true,
 						},
-						"password": &schema.Schema{
-							Type:     schema.TypeString,
+						"updated_at": schema.Int64Attribute{
 							Computed: true,
 						},
 					},
@@ -74,52 +103,38 @@ func dataSourceRepository() *schema.Resource {
 	}
 }
 
-func dataSourceRepositoriesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*Client)
-
-	var diags diag.Diagnostics
-
-	repositories, err := c.getRepositories()
+func (d *repositoriesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = clientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
 
+func (d *repositoriesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	repositories, err := d.client.Repositories.List(ctx)
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+		return
 	}
 
-	if err := d.Set("repositories", repoTerraform(&repositories)); err != nil {
-		return diag.FromErr(err)
+	state := repositoriesDataSourceModel{
+		// always run
+		Id:           types.StringValue(strconv.FormatInt(time.Now().Unix(), 10)),
+		Repositories: []repositoryDataSourceItem{},
 	}
-	// always run
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
-
-	return diags
-}
-
-func repoTerraform(repositories *[]Repository) []interface{} {
-	if repositories != nil {
-		j_repositories := make([]interface{}, len(*repositories))
-
-		for i, repository := range *repositories {
-			j_repo := make(map[string]interface{})
-
-			j_repo["created_at"] = repository.CreatedAt
-			j_repo["updated_at"] = repository.UpdatedAt
-			j_repo["name"] = repository.Name
-			j_repo["description"] = repository.Description
-			j_repo["folder_name"] = repository.FolderName
-			j_repo["uri"] = repository.Uri
-			j_repo["commit_hash"] = repository.CommitHash
-			j_repo["branch"] = repository.CommitHash
-			j_repo["branch"] = repository.CommitHash
-			j_repo["repo_type"] = repository.RepoType
-			j_repo["id"] = repository.Id
-			j_repo["username"] = repository.Username
-			j_repo["password"] = repository.Password
-
-			j_repositories[i] = j_repo
-
-		}
-		return j_repositories
+	for _, repository := range repositories {
+		state.Repositories = append(state.Repositories, repositoryDataSourceItem{
+			Id:          types.StringValue(repository.Id),
+			Name:        types.StringValue(repository.Name),
+			Description: types.StringValue(repository.Description),
+			FolderName:  types.StringValue(repository.FolderName),
+			Uri:         types.StringValue(repository.Uri),
+			CommitHash:  types.StringValue(repository.CommitHash),
+			Branch:      types.StringValue(repository.Branch),
+			RepoType:    types.StringValue(repository.RepoType),
+			Username:    types.StringValue(repository.Username),
+			Password:    types.StringValue(repository.Password),
+			CreatedAt:   types.Int64Value(repository.CreatedAt),
+			UpdatedAt:   types.Int64Value(repository.UpdatedAt),
+		})
 	}
 
-	return make([]interface{}, 0)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
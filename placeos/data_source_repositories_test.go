@@ -0,0 +1,58 @@
+package placeos
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestUnitDataSourceRepositories_basic(t *testing.T) {
+	engine := testFakeEngine(t)
+	engine.Seed("repositories", map[string]interface{}{
+		"name":        "Drivers",
+		"folder_name": "drivers",
+		"uri":         "https://github.com/placeos/drivers",
+		"repo_type":   "driver",
+		"branch":      "master",
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeProviderConfig(engine) + `
+data "placeos_repositories" "all" {}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.placeos_repositories.all", "repositories.#", "1"),
+					resource.TestCheckResourceAttr("data.placeos_repositories.all", "repositories.0.name", "Drivers"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceRepositories_basic(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-repositories")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRepositoryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRepositoryConfig(name, testAccDriversUri) + `
+data "placeos_repositories" "all" {
+  depends_on = [placeos_repository.test]
+}
+`,
+				Check: resource.TestCheckTypeSetElemNestedAttrs("data.placeos_repositories.all", "repositories.*", map[string]string{
+					"name": name,
+					"uri":  testAccDriversUri,
+				}),
+			},
+		},
+	})
+}
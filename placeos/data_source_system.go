@@ -0,0 +1,96 @@
+package placeos
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/api"
+)
+
+var (
+	_ datasource.DataSource              = &systemDataSource{}
+	_ datasource.DataSourceWithConfigure = &systemDataSource{}
+)
+
+type systemDataSource struct {
+	client *api.Client
+}
+
+func newSystemDataSource() datasource.DataSource {
+	return &systemDataSource{}
+}
+
+func (d *systemDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_system"
+}
+
+func (d *systemDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := systemDataSourceAttributes()
+	attributes["name"] = schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Validators:  []validator.String{stringvalidator.ExactlyOneOf(path.MatchRoot("email"))},
+		Description: "Exact name of the system, set either name or email.",
+	}
+	attributes["email"] = schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "Email of the system resource calendar, set either name or email.",
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Looks up a single system by its exact name or email.",
+		Attributes:  attributes,
+	}
+}
+
+func (d *systemDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = clientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *systemDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config systemDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the search narrows down names, emails are matched on the full list
+	options := api.SystemListOptions{}
+	attribute, value := "email", config.Email.ValueString()
+	if !config.Name.IsNull() {
+		attribute, value = "name", config.Name.ValueString()
+		options.Q = value
+	}
+
+	systems, err := d.client.Systems.List(ctx, options)
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+		return
+	}
+
+	var found []api.System
+	for _, system := range systems {
+		if (attribute == "name" && system.Name == value) || (attribute == "email" && system.Email == value) {
+			found = append(found, system)
+		}
+	}
+	switch len(found) {
+	case 0:
+		resp.Diagnostics.AddAttributeError(path.Root(attribute), "System not found", fmt.Sprintf("No system has the %s %q.", attribute, value))
+		return
+	case 1:
+	default:
+		resp.Diagnostics.AddAttributeError(path.Root(attribute), "Several systems found", fmt.Sprintf("%d systems have the %s %q, use placeos_systems to list them.", len(found), attribute, value))
+		return
+	}
+
+	state := systemDataSourceFromAPI(ctx, &found[0], &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
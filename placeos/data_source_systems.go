@@ -0,0 +1,237 @@
+package placeos
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/api"
+)
+
+var (
+	_ datasource.DataSource              = &systemsDataSource{}
+	_ datasource.DataSourceWithConfigure = &systemsDataSource{}
+)
+
+type systemsDataSource struct {
+	client *api.Client
+}
+
+type systemsDataSourceModel struct {
+	Id       types.String            `tfsdk:"id"`
+	Q        types.String            `tfsdk:"q"`
+	ZoneId   types.String            `tfsdk:"zone_id"`
+	ModuleId types.String            `tfsdk:"module_id"`
+	Features types.Set               `tfsdk:"features"`
+	Capacity types.Int64             `tfsdk:"capacity"`
+	Bookable types.Bool              `tfsdk:"bookable"`
+	Systems  []systemDataSourceModel `tfsdk:"systems"`
+}
+
+// systemDataSourceModel is a system as read by placeos_system and listed by
+// placeos_systems.
+type systemDataSourceModel struct {
+	Id                 types.String `tfsdk:"id"`
+	Name               types.String `tfsdk:"name"`
+	Description        types.String `tfsdk:"description"`
+	Email              types.String `tfsdk:"email"`
+	DisplayName        types.String `tfsdk:"display_name"`
+	Code               types.String `tfsdk:"code"`
+	Timezone           types.String `tfsdk:"timezone"`
+	SupportUrl         types.String `tfsdk:"support_url"`
+	MapId              types.String `tfsdk:"map_id"`
+	Bookable           types.Bool   `tfsdk:"bookable"`
+	Version            types.Int64  `tfsdk:"version"`
+	InstalledUiDevices types.Int64  `tfsdk:"installed_ui_devices"`
+	Capacity           types.Int64  `tfsdk:"capacity"`
+	Images             types.List   `tfsdk:"images"`
+	Zones              types.List   `tfsdk:"zones"`
+	Modules            types.List   `tfsdk:"modules"`
+	Features           types.List   `tfsdk:"features"`
+	CreatedAt          types.Int64  `tfsdk:"created_at"`
+	UpdatedAt          types.Int64  `tfsdk:"updated_at"`
+}
+
+func newSystemsDataSource() datasource.DataSource {
+	return &systemsDataSource{}
+}
+
+func (d *systemsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_systems"
+}
+
+func (d *systemsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the systems matching all the given filters.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"q": schema.StringAttribute{
+				Optional:    true,
+				Description: "Search query over the system fields.",
+			},
+			"zone_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only systems in this zone.",
+			},
+			"module_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only systems using this module.",
+			},
+			"features": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Only systems having all of these features.",
+			},
+			"capacity": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Only systems seating at least this many people.",
+			},
+			"bookable": schema.BoolAttribute{
+				Optional: true,
+			},
+			"systems": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: systemDataSourceAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// systemDataSourceAttributes are the computed attributes of a system read
+// by a data source. Modules are listed by id, in order.
+func systemDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed: true,
+		},
+		"name": schema.StringAttribute{
+			Computed: true,
+		},
+		"description": schema.StringAttribute{
+			Computed: true,
+		},
+		"email": schema.StringAttribute{
+			Computed: true,
+		},
+		"display_name": schema.StringAttribute{
+			Computed: true,
+		},
+		"code": schema.StringAttribute{
+			Computed: true,
+		},
+		"timezone": schema.StringAttribute{
+			Computed: true,
+		},
+		"support_url": schema.StringAttribute{
+			Computed: true,
+		},
+		"map_id": schema.StringAttribute{
+			Computed: true,
+		},
+		"bookable": schema.BoolAttribute{
+			Computed: true,
+		},
+		"version": schema.Int64Attribute{
+			Computed: true,
+		},
+		"installed_ui_devices": schema.Int64Attribute{
+			Computed: true,
+		},
+		"capacity": schema.Int64Attribute{
+			Computed: true,
+		},
+		"images": schema.ListAttribute{
+			ElementType: types.StringType,
+			Computed:    true,
+		},
+		"zones": schema.ListAttribute{
+			ElementType: types.StringType,
+			Computed:    true,
+		},
+		"modules": schema.ListAttribute{
+			ElementType: types.StringType,
+			Computed:    true,
+		},
+		"features": schema.ListAttribute{
+			ElementType: types.StringType,
+			Computed:    true,
+		},
+		"created_at": schema.Int64Attribute{
+			Computed: true,
+		},
+		"updated_at": schema.Int64Attribute{
+			Computed: true,
+		},
+	}
+}
+
+func (d *systemsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = clientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *systemsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state systemsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	options := api.SystemListOptions{
+		Q:        state.Q.ValueString(),
+		ZoneId:   state.ZoneId.ValueString(),
+		ModuleId: state.ModuleId.ValueString(),
+		Features: setStrings(ctx, state.Features, &resp.Diagnostics),
+		Capacity: state.Capacity.ValueInt64(),
+	}
+	if !state.Bookable.IsNull() {
+		options.Bookable = state.Bookable.ValueBoolPointer()
+	}
+
+	systems, err := d.client.Systems.List(ctx, options)
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+		return
+	}
+
+	// always run
+	state.Id = types.StringValue(strconv.FormatInt(time.Now().Unix(), 10))
+	state.Systems = []systemDataSourceModel{}
+	for _, system := range systems {
+		state.Systems = append(state.Systems, systemDataSourceFromAPI(ctx, &system, &resp.Diagnostics))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func systemDataSourceFromAPI(ctx context.Context, system *api.System, diags *diag.Diagnostics) systemDataSourceModel {
+	return systemDataSourceModel{
+		Id:                 types.StringValue(system.Id),
+		Name:               types.StringValue(system.Name),
+		Description:        types.StringValue(system.Description),
+		Email:              types.StringValue(system.Email),
+		DisplayName:        types.StringValue(system.DisplayName),
+		Code:               types.StringValue(system.Code),
+		Timezone:           types.StringValue(system.Timezone),
+		SupportUrl:         types.StringValue(system.SupportUrl),
+		MapId:              types.StringValue(system.MapId),
+		Bookable:           types.BoolValue(system.Bookable),
+		Version:            types.Int64Value(system.Version),
+		InstalledUiDevices: types.Int64Value(system.InstalledUiDevices),
+		Capacity:           types.Int64Value(system.Capacity),
+		Images:             stringList(ctx, system.Images, diags),
+		Zones:              stringList(ctx, system.Zones, diags),
+		Modules:            stringList(ctx, system.Modules, diags),
+		Features:           stringList(ctx, system.Features, diags),
+		CreatedAt:          types.Int64Value(system.CreatedAt),
+		UpdatedAt:          types.Int64Value(system.UpdatedAt),
+	}
+}
@@ -0,0 +1,129 @@
+package placeos
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestUnitDataSourceSystems_basic(t *testing.T) {
+	engine := testFakeEngine(t)
+	zoneId := engine.Seed("zones", map[string]interface{}{"name": "Level 1", "tags": []string{"level"}})
+	moduleId := engine.Seed("modules", map[string]interface{}{"driver_id": "driver-1"})
+	largeId := engine.Seed("systems", map[string]interface{}{
+		"name":     "Boardroom",
+		"email":    "boardroom@example.com",
+		"zones":    []string{zoneId},
+		"modules":  []string{moduleId},
+		"features": []string{"vc", "display"},
+		"capacity": 20,
+		"bookable": true,
+	})
+	engine.Seed("systems", map[string]interface{}{
+		"name":     "Huddle room",
+		"email":    "huddle@example.com",
+		"zones":    []string{zoneId},
+		"features": []string{"display"},
+		"capacity": 4,
+		"bookable": false,
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeProviderConfig(engine) + fmt.Sprintf(`
+data "placeos_systems" "level" {
+  zone_id = %[1]q
+}
+
+data "placeos_systems" "vc" {
+  zone_id  = %[1]q
+  features = ["vc", "display"]
+  capacity = 10
+  bookable = true
+}
+
+data "placeos_systems" "module" {
+  module_id = %[2]q
+}
+
+data "placeos_system" "by_email" {
+  email = "huddle@example.com"
+}
+
+data "placeos_system" "by_name" {
+  name = "Boardroom"
+}
+`, zoneId, moduleId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.placeos_systems.level", "systems.#", "2"),
+					resource.TestCheckResourceAttr("data.placeos_systems.vc", "systems.#", "1"),
+					resource.TestCheckResourceAttr("data.placeos_systems.vc", "systems.0.id", largeId),
+					resource.TestCheckResourceAttr("data.placeos_systems.vc", "systems.0.capacity", "20"),
+					resource.TestCheckResourceAttr("data.placeos_systems.vc", "systems.0.zones.0", zoneId),
+					resource.TestCheckResourceAttr("data.placeos_systems.module", "systems.#", "1"),
+					resource.TestCheckResourceAttr("data.placeos_systems.module", "systems.0.modules.0", moduleId),
+					resource.TestCheckResourceAttr("data.placeos_system.by_email", "name", "Huddle room"),
+					resource.TestCheckResourceAttr("data.placeos_system.by_email", "bookable", "false"),
+					resource.TestCheckResourceAttr("data.placeos_system.by_name", "id", largeId),
+					resource.TestCheckResourceAttr("data.placeos_system.by_name", "email", "boardroom@example.com"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitDataSourceSystem_notFound(t *testing.T) {
+	engine := testFakeEngine(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeProviderConfig(engine) + `
+data "placeos_system" "test" {
+  name = "Boardroom"
+}
+`,
+				ExpectError: regexp.MustCompile(`No system has the name "Boardroom"`),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceSystems_basic(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-systems")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckSystemDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSystemConfig(name, 8) + `
+data "placeos_systems" "zone" {
+  zone_id = placeos_zone.test.id
+
+  depends_on = [placeos_system.test]
+}
+
+data "placeos_system" "test" {
+  name = placeos_system.test.name
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.placeos_systems.zone", "systems.#", "1"),
+					resource.TestCheckResourceAttrPair("data.placeos_systems.zone", "systems.0.id", "placeos_system.test", "id"),
+					resource.TestCheckResourceAttrPair("data.placeos_system.test", "id", "placeos_system.test", "id"),
+					resource.TestCheckResourceAttr("data.placeos_system.test", "capacity", "8"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,96 @@
+package placeos
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/api"
+)
+
+var (
+	_ datasource.DataSource              = &zoneDataSource{}
+	_ datasource.DataSourceWithConfigure = &zoneDataSource{}
+)
+
+type zoneDataSource struct {
+	client *api.Client
+}
+
+func newZoneDataSource() datasource.DataSource {
+	return &zoneDataSource{}
+}
+
+func (d *zoneDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone"
+}
+
+func (d *zoneDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := zoneDataSourceAttributes()
+	attributes["name"] = schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Validators:  []validator.String{stringvalidator.ExactlyOneOf(path.MatchRoot("code"))},
+		Description: "Exact name of the zone, set either name or code.",
+	}
+	attributes["code"] = schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "Exact code of the zone, set either name or code.",
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Looks up a single zone by its exact name or code.",
+		Attributes:  attributes,
+	}
+}
+
+func (d *zoneDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = clientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *zoneDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config zoneDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the search narrows down names, codes are matched on the full list
+	options := api.ZoneListOptions{}
+	attribute, value := "code", config.Code.ValueString()
+	if !config.Name.IsNull() {
+		attribute, value = "name", config.Name.ValueString()
+		options.Q = value
+	}
+
+	zones, err := d.client.Zones.List(ctx, options)
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+		return
+	}
+
+	var found []api.Zone
+	for _, zone := range zones {
+		if (attribute == "name" && zone.Name == value) || (attribute == "code" && zone.Code == value) {
+			found = append(found, zone)
+		}
+	}
+	switch len(found) {
+	case 0:
+		resp.Diagnostics.AddAttributeError(path.Root(attribute), "Zone not found", fmt.Sprintf("No zone has the %s %q.", attribute, value))
+		return
+	case 1:
+	default:
+		resp.Diagnostics.AddAttributeError(path.Root(attribute), "Several zones found", fmt.Sprintf("%d zones have the %s %q, use placeos_zones to list them.", len(found), attribute, value))
+		return
+	}
+
+	state := zoneDataSourceFromAPI(ctx, &found[0], &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
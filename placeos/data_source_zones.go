@@ -0,0 +1,188 @@
+package placeos
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/api"
+)
+
+var (
+	_ datasource.DataSource              = &zonesDataSource{}
+	_ datasource.DataSourceWithConfigure = &zonesDataSource{}
+)
+
+type zonesDataSource struct {
+	client *api.Client
+}
+
+type zonesDataSourceModel struct {
+	Id       types.String          `tfsdk:"id"`
+	Q        types.String          `tfsdk:"q"`
+	Tags     types.Set             `tfsdk:"tags"`
+	ParentId types.String          `tfsdk:"parent_id"`
+	Zones    []zoneDataSourceModel `tfsdk:"zones"`
+}
+
+// zoneDataSourceModel is a zone as read by placeos_zone and listed by
+// placeos_zones.
+type zoneDataSourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Tags        types.Set    `tfsdk:"tags"`
+	Description types.String `tfsdk:"description"`
+	DisplayName types.String `tfsdk:"display_name"`
+	Code        types.String `tfsdk:"code"`
+	Type        types.String `tfsdk:"type"`
+	Location    types.String `tfsdk:"location"`
+	CountField  types.Int64  `tfsdk:"count_field"`
+	Capacity    types.Int64  `tfsdk:"capacity"`
+	MapId       types.String `tfsdk:"map_id"`
+	ParentId    types.String `tfsdk:"parent_id"`
+	CreatedAt   types.Int64  `tfsdk:"created_at"`
+	UpdatedAt   types.Int64  `tfsdk:"updated_at"`
+}
+
+func newZonesDataSource() datasource.DataSource {
+	return &zonesDataSource{}
+}
+
+func (d *zonesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zones"
+}
+
+func (d *zonesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the zones matching all the given filters.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"q": schema.StringAttribute{
+				Optional:    true,
+				Description: "Search query over the zone fields.",
+			},
+			"tags": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Only zones carrying any of these tags.",
+			},
+			"parent_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only the children of this zone.",
+			},
+			"zones": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: zoneDataSourceAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// zoneDataSourceAttributes are the computed attributes of a zone read by a
+// data source.
+func zoneDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed: true,
+		},
+		"name": schema.StringAttribute{
+			Computed: true,
+		},
+		"tags": schema.SetAttribute{
+			ElementType: types.StringType,
+			Computed:    true,
+		},
+		"description": schema.StringAttribute{
+			Computed: true,
+		},
+		"display_name": schema.StringAttribute{
+			Computed: true,
+		},
+		"code": schema.StringAttribute{
+			Computed: true,
+		},
+		"type": schema.StringAttribute{
+			Computed: true,
+		},
+		"location": schema.StringAttribute{
+			Computed: true,
+		},
+		"count_field": schema.Int64Attribute{
+			Computed: true,
+		},
+		"capacity": schema.Int64Attribute{
+			Computed: true,
+		},
+		"map_id": schema.StringAttribute{
+			Computed: true,
+		},
+		"parent_id": schema.StringAttribute{
+			Computed: true,
+		},
+		"created_at": schema.Int64Attribute{
+			Computed: true,
+		},
+		"updated_at": schema.Int64Attribute{
+			Computed: true,
+		},
+	}
+}
+
+func (d *zonesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = clientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *zonesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state zonesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zones, err := d.client.Zones.List(ctx, api.ZoneListOptions{
+		Q:        state.Q.ValueString(),
+		Tags:     setStrings(ctx, state.Tags, &resp.Diagnostics),
+		ParentId: state.ParentId.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+		return
+	}
+
+	// always run
+	state.Id = types.StringValue(strconv.FormatInt(time.Now().Unix(), 10))
+	state.Zones = []zoneDataSourceModel{}
+	for _, zone := range zones {
+		state.Zones = append(state.Zones, zoneDataSourceFromAPI(ctx, &zone, &resp.Diagnostics))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func zoneDataSourceFromAPI(ctx context.Context, zone *api.Zone, diags *diag.Diagnostics) zoneDataSourceModel {
+	return zoneDataSourceModel{
+		Id:          types.StringValue(zone.Id),
+		Name:        types.StringValue(zone.Name),
+		Tags:        stringSet(ctx, zone.Tags, diags),
+		Description: types.StringValue(zone.Description),
+		DisplayName: types.StringValue(zone.DisplayName),
+		Code:        types.StringValue(zone.Code),
+		Type:        types.StringValue(zone.Type),
+		Location:    types.StringValue(zone.Location),
+		CountField:  types.Int64Value(int64(zone.Count)),
+		Capacity:    types.Int64Value(int64(zone.Capacity)),
+		MapId:       types.StringValue(zone.MapId),
+		ParentId:    types.StringValue(zone.ParentId),
+		CreatedAt:   types.Int64Value(zone.CreatedAt),
+		UpdatedAt:   types.Int64Value(zone.UpdatedAt),
+	}
+}
@@ -0,0 +1,117 @@
+package placeos
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestUnitDataSourceZones_basic(t *testing.T) {
+	engine := testFakeEngine(t)
+	orgId := engine.Seed("zones", map[string]interface{}{"name": "PlaceOS", "tags": []string{"org"}})
+	buildingId := engine.Seed("zones", map[string]interface{}{"name": "Sydney", "code": "SYD", "tags": []string{"building"}, "parent_id": orgId})
+	engine.Seed("zones", map[string]interface{}{"name": "Melbourne", "code": "MEL", "tags": []string{"building"}, "parent_id": orgId})
+	engine.Seed("zones", map[string]interface{}{"name": "Sydney Level 1", "tags": []string{"level"}, "parent_id": buildingId})
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeProviderConfig(engine) + fmt.Sprintf(`
+data "placeos_zones" "buildings" {
+  tags      = ["building"]
+  parent_id = %q
+}
+
+data "placeos_zones" "sydney" {
+  q = "sydney"
+}
+
+data "placeos_zone" "by_code" {
+  code = "SYD"
+}
+
+data "placeos_zone" "by_name" {
+  name = "Sydney Level 1"
+}
+`, orgId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.placeos_zones.buildings", "zones.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.placeos_zones.buildings", "zones.*", map[string]string{
+						"id":   buildingId,
+						"name": "Sydney",
+						"code": "SYD",
+					}),
+					resource.TestCheckResourceAttr("data.placeos_zones.sydney", "zones.#", "2"),
+					resource.TestCheckResourceAttr("data.placeos_zone.by_code", "id", buildingId),
+					resource.TestCheckResourceAttr("data.placeos_zone.by_code", "name", "Sydney"),
+					resource.TestCheckTypeSetElemAttr("data.placeos_zone.by_code", "tags.*", "building"),
+					resource.TestCheckResourceAttr("data.placeos_zone.by_name", "parent_id", buildingId),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitDataSourceZone_notFound(t *testing.T) {
+	engine := testFakeEngine(t)
+	engine.Seed("zones", map[string]interface{}{"name": "Sydney", "tags": []string{"building"}})
+	engine.Seed("zones", map[string]interface{}{"name": "Sydney", "tags": []string{"building"}})
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeProviderConfig(engine) + `
+data "placeos_zone" "test" {
+  code = "SYD"
+}
+`,
+				ExpectError: regexp.MustCompile(`No zone has the code "SYD"`),
+			},
+			{
+				Config: testFakeProviderConfig(engine) + `
+data "placeos_zone" "test" {
+  name = "Sydney"
+}
+`,
+				ExpectError: regexp.MustCompile(`2 zones have the name "Sydney"`),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceZones_basic(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-zones")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckZoneDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZoneConfig(name, 10, 1) + `
+data "placeos_zones" "buildings" {
+  q    = placeos_zone.test.name
+  tags = ["building"]
+}
+
+data "placeos_zone" "test" {
+  name = placeos_zone.test.name
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("data.placeos_zones.buildings", "zones.*", map[string]string{
+						"name": name,
+					}),
+					resource.TestCheckResourceAttrPair("data.placeos_zone.test", "id", "placeos_zone.test", "id"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,49 @@
+package placeos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/api"
+)
+
+// attributeSchema is the part of a plan, state or config schema used to
+// tell whether a field reported by the engine is an attribute.
+type attributeSchema interface {
+	TypeAtPath(ctx context.Context, p path.Path) (attr.Type, diag.Diagnostics)
+}
+
+// diagnosticsFromErr converts a client error into diagnostics. Validation
+// failures reported by the engine are attached to the matching attribute of
+// schema, those about a field schema does not have, or when schema is nil,
+// are reported without one.
+func diagnosticsFromErr(ctx context.Context, err error, schema attributeSchema) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var apiError *api.APIError
+	if !errors.As(err, &apiError) || len(apiError.Failures) == 0 {
+		diags.AddError("PlaceOS API error", err.Error())
+		return diags
+	}
+
+	for _, failure := range apiError.Failures {
+		summary := fmt.Sprintf("Invalid %s", failure.Field)
+		detail := fmt.Sprintf("PlaceOS rejected %s %s: %s %s", apiError.Method, apiError.Path, failure.Field, failure.Reason)
+		if schema == nil || failure.Field == "" {
+			diags.AddError(summary, detail)
+			continue
+		}
+		if _, typeDiags := schema.TypeAtPath(ctx, path.Root(failure.Field)); typeDiags.HasError() {
+			diags.AddError(summary, detail)
+			continue
+		}
+		diags.AddAttributeError(path.Root(failure.Field), summary, detail)
+	}
+
+	return diags
+}
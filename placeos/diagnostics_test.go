@@ -0,0 +1,58 @@
+package placeos
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/api"
+)
+
+func TestDiagnosticsFromErr(t *testing.T) {
+	ctx := context.Background()
+	var schema fwresource.SchemaResponse
+	newZoneResource().Schema(ctx, fwresource.SchemaRequest{}, &schema)
+
+	validation := &api.APIError{
+		StatusCode: http.StatusUnprocessableEntity,
+		Method:     http.MethodPost,
+		Path:       "/api/engine/v2/zones",
+		Failures: []api.FieldFailure{
+			{Field: "parent_id", Reason: "does not exist"},
+			{Field: "parentZone", Reason: "is invalid"},
+		},
+	}
+
+	t.Run("error", func(t *testing.T) {
+		diags := diagnosticsFromErr(ctx, errors.New("connection refused"), schema.Schema)
+		if len(diags) != 1 || diags[0].Summary() != "PlaceOS API error" || diags[0].Detail() != "connection refused" {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+	})
+
+	t.Run("failures", func(t *testing.T) {
+		diags := diagnosticsFromErr(ctx, validation, schema.Schema)
+		if len(diags) != 2 {
+			t.Fatalf("expected 2 diagnostics, got %v", diags)
+		}
+		if withPath, ok := diags[0].(diag.DiagnosticWithPath); !ok || !withPath.Path().Equal(path.Root("parent_id")) {
+			t.Errorf("expected an error on parent_id, got %v", diags[0])
+		}
+		if _, ok := diags[1].(diag.DiagnosticWithPath); ok {
+			t.Errorf("expected parentZone to be reported without an attribute, got %v", diags[1])
+		}
+	})
+
+	t.Run("without schema", func(t *testing.T) {
+		for _, d := range diagnosticsFromErr(ctx, validation, nil) {
+			if _, ok := d.(diag.DiagnosticWithPath); ok {
+				t.Errorf("expected no attribute without a schema, got %v", d)
+			}
+		}
+	})
+}
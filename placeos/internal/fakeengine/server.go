@@ -0,0 +1,777 @@
+// Package fakeengine is an in-memory stand-in for the PlaceOS engine API.
+// It serves the OAuth token endpoint and the engine collections used by
+// the provider, so the client and the resources can be tested offline.
+package fakeengine
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Credentials accepted by the fake engine.
+const (
+	ClientId     = "fake-client-id"
+	ClientSecret = "fake-client-secret"
+	Username     = "support@place.tech"
+	Password     = "development"
+	ApiKey       = "fake-api-key"
+)
+
+// CommitHash is returned as the latest commit of every repository.
+const CommitHash = "f1c2d3e4"
+
+const apiPrefix = "/api/engine/v2/"
+
+// idPrefixes lists the engine collections served and the prefix of the ids
+// generated for them. Trigger instances are only served under their
+// system, /systems/{id}/triggers.
+var idPrefixes = map[string]string{
+	"systems":           "sys-",
+	"zones":             "zone-",
+	"drivers":           "driver-",
+	"modules":           "mod-",
+	"settings":          "sets-",
+	"repositories":      "repo-",
+	"triggers":          "trigger-",
+	"trigger_instances": "inst-",
+}
+
+// requiredFields are validated on create, a missing one answers 422 like
+// the engine does.
+var requiredFields = map[string][]string{
+	"systems":      {"name"},
+	"zones":        {"name"},
+	"drivers":      {"name", "file_name", "module_name"},
+	"modules":      {"driver_id"},
+	"settings":     {"parent_id"},
+	"repositories": {"name", "uri"},
+	"triggers":     {"name"},
+}
+
+type object = map[string]interface{}
+
+type failure struct {
+	method string
+	path   string
+	times  int
+	status int
+	body   string
+}
+
+type Server struct {
+	*httptest.Server
+
+	// TokenLifetime is the expires_in reported for issued access tokens.
+	TokenLifetime time.Duration
+	// PageLinks adds a Link rel="next" header to every list page but the
+	// last, as the engine does. Clear it to test offset paging.
+	PageLinks bool
+
+	mu            sync.Mutex
+	sequence      int
+	collections   map[string]map[string]object
+	metadata      map[string]map[string]object
+	tokens        map[string]bool
+	refreshTokens map[string]bool
+	failures      []*failure
+	requests      []string
+}
+
+// New starts a fake engine. Callers must Close it.
+func New() *Server {
+	s := &Server{
+		TokenLifetime: time.Hour,
+		PageLinks:     true,
+		collections:   map[string]map[string]object{},
+		metadata:      map[string]map[string]object{},
+		tokens:        map[string]bool{},
+		refreshTokens: map[string]bool{},
+	}
+	for collection := range idPrefixes {
+		s.collections[collection] = map[string]object{}
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// Fail makes the next times requests matching method and path answer with
+// status and body instead of being served. path matches by prefix.
+func (s *Server) Fail(method string, path string, times int, status int, body string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = append(s.failures, &failure{method: method, path: path, times: times, status: status, body: body})
+}
+
+// RevokeTokens invalidates every access token issued so far, as if they
+// had expired on the engine side.
+func (s *Server) RevokeTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens = map[string]bool{}
+}
+
+// Requests returns every request served so far as "METHOD /path".
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.requests...)
+}
+
+// Object returns a copy of a stored object.
+func (s *Server) Object(collection string, id string) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.collections[collection][id]
+	if !ok {
+		return nil, false
+	}
+
+	return copyObject(stored), true
+}
+
+// IDs returns the ids stored in a collection, sorted.
+func (s *Server) IDs(collection string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var ids []string
+	for id := range s.collections[collection] {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+// Metadata returns a copy of the metadata called name of parentId.
+func (s *Server) Metadata(parentId string, name string) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.metadata[parentId][name]
+	if !ok {
+		return nil, false
+	}
+
+	return copyObject(stored), true
+}
+
+// Seed stores an object as if it had been created outside of the test and
+// returns its id.
+func (s *Server) Seed(collection string, fields map[string]interface{}) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.create(collection, fields)["id"].(string)
+}
+
+// Remove deletes an object behind the client's back.
+func (s *Server) Remove(collection string, id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.collections[collection], id)
+}
+
+// Modify changes an object behind the client's back, as an edit made in
+// Backoffice would.
+func (s *Server) Modify(collection string, id string, fields map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if stored, ok := s.collections[collection][id]; ok {
+		s.update(collection, stored, fields)
+	}
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+
+	for i, f := range s.failures {
+		if f.method == r.Method && strings.HasPrefix(r.URL.Path, f.path) {
+			f.times--
+			if f.times <= 0 {
+				s.failures = append(s.failures[:i], s.failures[i+1:]...)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(f.status)
+			w.Write([]byte(f.body))
+			return
+		}
+	}
+
+	if r.URL.Path == "/auth/oauth/token" {
+		s.handleToken(w, r)
+		return
+	}
+
+	if !strings.HasPrefix(r.URL.Path, apiPrefix) {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	if !s.authenticated(r) {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var body object
+	if r.Body != nil && (r.Method == http.MethodPost || r.Method == http.MethodPut || r.Method == http.MethodPatch) {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil && err != io.EOF {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	segments := strings.Split(strings.TrimPrefix(r.URL.Path, apiPrefix), "/")
+	s.route(w, r, segments, body)
+}
+
+func (s *Server) route(w http.ResponseWriter, r *http.Request, segments []string, body object) {
+	collection := segments[0]
+	if collection == "metadata" && len(segments) == 2 {
+		s.routeMetadata(w, r, segments[1], body)
+		return
+	}
+
+	items, ok := s.collections[collection]
+	if !ok || collection == "trigger_instances" {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	switch {
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		s.writePage(w, r, s.filter(s.list(collection), r.URL.Query()))
+
+	case len(segments) == 1 && r.Method == http.MethodPost:
+		if failures := validate(collection, body); len(failures) > 0 {
+			writeJSON(w, http.StatusUnprocessableEntity, object{"error": "validation failed", "failures": failures})
+			return
+		}
+		writeJSON(w, http.StatusCreated, copyObject(s.create(collection, body)))
+
+	case len(segments) == 2:
+		stored, ok := items[segments[1]]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("%s %s not found", collection, segments[1]))
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, copyObject(stored))
+		case http.MethodPut, http.MethodPatch:
+			if version, ok := body["version"]; ok && collection == "systems" && toInt(version) != toInt(stored["version"]) {
+				writeError(w, http.StatusConflict, "version mismatch")
+				return
+			}
+			writeJSON(w, http.StatusOK, copyObject(s.update(collection, stored, body)))
+		case http.MethodDelete:
+			delete(items, segments[1])
+			delete(s.metadata, segments[1])
+			switch collection {
+			case "modules":
+				s.dropModule(segments[1])
+			case "systems":
+				for id, instance := range s.collections["trigger_instances"] {
+					if instance["control_system_id"] == segments[1] {
+						delete(s.collections["trigger_instances"], id)
+					}
+				}
+			}
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+
+	case collection == "repositories" && len(segments) == 3 && segments[2] == "commits" && r.Method == http.MethodGet:
+		if _, ok := items[segments[1]]; !ok {
+			writeError(w, http.StatusNotFound, "repository not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, []object{{
+			"commit":  CommitHash,
+			"date":    time.Now().UTC().Format(time.RFC3339),
+			"author":  "Fake Engine",
+			"subject": "latest",
+		}})
+
+	case collection == "systems" && len(segments) == 3 && (segments[2] == "start" || segments[2] == "stop") && r.Method == http.MethodPost:
+		stored, ok := items[segments[1]]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("systems %s not found", segments[1]))
+			return
+		}
+		s.setRunning(stored, segments[2] == "start")
+		w.WriteHeader(http.StatusOK)
+
+	case collection == "systems" && len(segments) >= 3 && segments[2] == "triggers":
+		if _, ok := items[segments[1]]; !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("systems %s not found", segments[1]))
+			return
+		}
+		s.routeTriggerInstances(w, r, segments[1], segments[3:], body)
+
+	case collection == "systems" && len(segments) == 4 && segments[2] == "module":
+		stored, ok := items[segments[1]]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("systems %s not found", segments[1]))
+			return
+		}
+		if _, ok := s.collections["modules"][segments[3]]; !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("modules %s not found", segments[3]))
+			return
+		}
+
+		switch r.Method {
+		case http.MethodPut:
+			writeJSON(w, http.StatusOK, copyObject(s.addModule(stored, segments[3])))
+		case http.MethodDelete:
+			writeJSON(w, http.StatusOK, copyObject(s.removeModule(stored, segments[3])))
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+// routeMetadata serves /metadata/{parent_id}, documents are addressed by
+// the name query parameter on GET and DELETE and by the body on PUT.
+func (s *Server) routeMetadata(w http.ResponseWriter, r *http.Request, parentId string, body object) {
+	if !s.exists(parentId) {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("%s not found", parentId))
+		return
+	}
+	name := r.URL.Query().Get("name")
+
+	switch r.Method {
+	case http.MethodGet:
+		found := object{}
+		for key, metadata := range s.metadata[parentId] {
+			if name == "" || name == key {
+				found[key] = copyObject(metadata)
+			}
+		}
+		writeJSON(w, http.StatusOK, found)
+
+	case http.MethodPut:
+		name, _ := body["name"].(string)
+		if name == "" {
+			writeJSON(w, http.StatusUnprocessableEntity, object{"error": "validation failed", "failures": []object{{"field": "name", "reason": "is required"}}})
+			return
+		}
+		if s.metadata[parentId] == nil {
+			s.metadata[parentId] = map[string]object{}
+		}
+
+		stored := copyObject(body)
+		stored["parent_id"] = parentId
+		stored["updated_at"] = time.Now().Unix()
+		if stored["editors"] == nil {
+			stored["editors"] = []interface{}{}
+		}
+		s.metadata[parentId][name] = stored
+		writeJSON(w, http.StatusOK, copyObject(stored))
+
+	case http.MethodDelete:
+		delete(s.metadata[parentId], name)
+		w.WriteHeader(http.StatusAccepted)
+
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// exists reports whether id is stored in any collection.
+func (s *Server) exists(id string) bool {
+	for _, items := range s.collections {
+		if _, ok := items[id]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// routeTriggerInstances serves /systems/{id}/triggers and
+// /systems/{id}/triggers/{instance_id}.
+func (s *Server) routeTriggerInstances(w http.ResponseWriter, r *http.Request, systemId string, segments []string, body object) {
+	instances := s.collections["trigger_instances"]
+
+	if len(segments) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			list := []object{}
+			for _, instance := range s.list("trigger_instances") {
+				if instance["control_system_id"] == systemId {
+					list = append(list, instance)
+				}
+			}
+			s.writePage(w, r, list)
+		case http.MethodPost:
+			triggerId, _ := body["trigger_id"].(string)
+			if _, ok := s.collections["triggers"][triggerId]; !ok {
+				writeJSON(w, http.StatusUnprocessableEntity, object{"error": "validation failed", "failures": []object{{"field": "trigger_id", "reason": "must reference an existing trigger"}}})
+				return
+			}
+			body["control_system_id"] = systemId
+			instance := s.create("trigger_instances", body)
+			instance["webhook_secret"] = fmt.Sprintf("secret-%d", s.sequence)
+			writeJSON(w, http.StatusCreated, copyObject(instance))
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+		return
+	}
+
+	instance, ok := instances[segments[0]]
+	if !ok || len(segments) > 1 || instance["control_system_id"] != systemId {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("trigger instance %s not found", segments[0]))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, copyObject(instance))
+	case http.MethodPatch, http.MethodPut:
+		writeJSON(w, http.StatusOK, copyObject(s.update("trigger_instances", instance, body)))
+	case http.MethodDelete:
+		delete(instances, segments[0])
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// addModule appends moduleId to the modules of system unless it is already
+// there.
+func (s *Server) addModule(system object, moduleId string) object {
+	modules := toStrings(system["modules"])
+	for _, id := range modules {
+		if id == moduleId {
+			return system
+		}
+	}
+
+	return s.update("systems", system, object{"modules": append(modules, moduleId)})
+}
+
+// removeModule takes moduleId out of the modules of system. Like the engine,
+// the module itself is deleted once no system references it.
+func (s *Server) removeModule(system object, moduleId string) object {
+	modules := []string{}
+	for _, id := range toStrings(system["modules"]) {
+		if id != moduleId {
+			modules = append(modules, id)
+		}
+	}
+	system = s.update("systems", system, object{"modules": modules})
+
+	for _, other := range s.collections["systems"] {
+		for _, id := range toStrings(other["modules"]) {
+			if id == moduleId {
+				return system
+			}
+		}
+	}
+	delete(s.collections["modules"], moduleId)
+
+	return system
+}
+
+// setRunning starts or stops the modules of system, except the ones that
+// ignore start and stop.
+func (s *Server) setRunning(system object, running bool) {
+	for _, id := range toStrings(system["modules"]) {
+		module, ok := s.collections["modules"][id]
+		if !ok {
+			continue
+		}
+		if ignore, _ := module["ignore_startstop"].(bool); ignore {
+			continue
+		}
+		module["running"] = running
+	}
+}
+
+// dropModule takes a deleted module out of every system, as the engine
+// does.
+func (s *Server) dropModule(moduleId string) {
+	for _, system := range s.collections["systems"] {
+		modules := []string{}
+		for _, id := range toStrings(system["modules"]) {
+			if id != moduleId {
+				modules = append(modules, id)
+			}
+		}
+		if len(modules) != len(toStrings(system["modules"])) {
+			s.update("systems", system, object{"modules": modules})
+		}
+	}
+}
+
+func (s *Server) list(collection string) []object {
+	var ids []string
+	for id := range s.collections[collection] {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	list := make([]object, 0, len(ids))
+	for _, id := range ids {
+		list = append(list, copyObject(s.collections[collection][id]))
+	}
+
+	return list
+}
+
+// filter keeps the objects matching the query parameters of a list
+// request. q searches the name, tags matches any of the comma separated
+// tags, features all of them and capacity is a minimum. zone_id and
+// module_id look into the zones and modules of systems. control_system_id
+// keeps the modules of a system, no_logic the modules that are not logic.
+// Any other parameter must equal the field of the same name, or be one of
+// its elements for arrays, a missing boolean is false.
+func (s *Server) filter(items []object, query url.Values) []object {
+	matched := []object{}
+	for _, item := range items {
+		keep := true
+		for key, values := range query {
+			value := values[0]
+			switch key {
+			case "limit", "offset":
+			case "q":
+				name, _ := item["name"].(string)
+				keep = keep && strings.Contains(strings.ToLower(name), strings.ToLower(value))
+			case "tags":
+				tags := toStrings(item["tags"])
+				keep = keep && slices.ContainsFunc(strings.Split(value, ","), func(tag string) bool {
+					return slices.Contains(tags, tag)
+				})
+			case "features":
+				features := toStrings(item["features"])
+				for _, feature := range strings.Split(value, ",") {
+					keep = keep && slices.Contains(features, feature)
+				}
+			case "capacity":
+				capacity, _ := strconv.Atoi(value)
+				keep = keep && toInt(item["capacity"]) >= capacity
+			case "zone_id":
+				keep = keep && slices.Contains(toStrings(item["zones"]), value)
+			case "module_id":
+				keep = keep && slices.Contains(toStrings(item["modules"]), value)
+			case "control_system_id":
+				system, ok := s.collections["systems"][value]
+				keep = keep && ok && slices.Contains(toStrings(system["modules"]), item["id"].(string))
+			case "no_logic":
+				keep = keep && (value != "true" || toInt(item["role"]) != 99)
+			default:
+				switch field := item[key].(type) {
+				case []interface{}, []string:
+					keep = keep && slices.Contains(toStrings(field), value)
+				case nil:
+					keep = keep && value == "false"
+				default:
+					keep = keep && fmt.Sprint(field) == value
+				}
+			}
+		}
+		if keep {
+			matched = append(matched, item)
+		}
+	}
+
+	return matched
+}
+
+// writePage answers a list request with the window selected by limit and
+// offset, the total in X-Total-Count and, with PageLinks, the next page.
+func (s *Server) writePage(w http.ResponseWriter, r *http.Request, items []object) {
+	query := r.URL.Query()
+	total := len(items)
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	offset = min(max(offset, 0), total)
+	end := total
+	if limit, err := strconv.Atoi(query.Get("limit")); err == nil && limit >= 0 {
+		end = min(offset+limit, total)
+	}
+
+	if s.PageLinks && end < total {
+		query.Set("offset", strconv.Itoa(end))
+		w.Header().Set("Link", fmt.Sprintf(`<%s?%s>; rel="next"`, r.URL.Path, query.Encode()))
+	}
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	writeJSON(w, http.StatusOK, items[offset:end])
+}
+
+func (s *Server) create(collection string, fields object) object {
+	s.sequence++
+	now := time.Now().Unix()
+
+	stored := copyObject(fields)
+	stored["id"] = fmt.Sprintf("%s%d", idPrefixes[collection], s.sequence)
+	stored["created_at"] = now
+	stored["updated_at"] = now
+	if collection == "systems" {
+		stored["version"] = 0
+	}
+
+	s.collections[collection][stored["id"].(string)] = stored
+	return stored
+}
+
+func (s *Server) update(collection string, stored object, fields object) object {
+	for key, value := range fields {
+		switch key {
+		case "id", "created_at", "updated_at", "version":
+			continue
+		}
+		stored[key] = value
+	}
+
+	stored["updated_at"] = time.Now().Unix()
+	if collection == "systems" {
+		stored["version"] = toInt(stored["version"]) + 1
+	}
+
+	return stored
+}
+
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	expected := base64.StdEncoding.EncodeToString([]byte(ClientId + ":" + ClientSecret))
+	if r.Header.Get("Authorization") != "Basic "+expected {
+		writeJSON(w, http.StatusUnauthorized, object{"error": "invalid_client"})
+		return
+	}
+
+	var params map[string]string
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		writeJSON(w, http.StatusBadRequest, object{"error": "invalid_request"})
+		return
+	}
+
+	switch params["grant_type"] {
+	case "password":
+		if params["username"] != Username || params["password"] != Password {
+			writeJSON(w, http.StatusUnauthorized, object{"error": "invalid_grant"})
+			return
+		}
+	case "client_credentials":
+	case "refresh_token":
+		if !s.refreshTokens[params["refresh_token"]] {
+			writeJSON(w, http.StatusUnauthorized, object{"error": "invalid_grant"})
+			return
+		}
+		delete(s.refreshTokens, params["refresh_token"])
+	default:
+		writeJSON(w, http.StatusBadRequest, object{"error": "unsupported_grant_type"})
+		return
+	}
+
+	s.sequence++
+	accessToken := fmt.Sprintf("access-%d", s.sequence)
+	refreshToken := fmt.Sprintf("refresh-%d", s.sequence)
+	s.tokens[accessToken] = true
+	s.refreshTokens[refreshToken] = true
+
+	writeJSON(w, http.StatusOK, object{
+		"access_token":  accessToken,
+		"token_type":    "Bearer",
+		"expires_in":    int64(s.TokenLifetime / time.Second),
+		"refresh_token": refreshToken,
+		"scope":         params["scope"],
+		"created_at":    time.Now().Unix(),
+	})
+}
+
+func (s *Server) authenticated(r *http.Request) bool {
+	if r.Header.Get("X-API-Key") == ApiKey {
+		return true
+	}
+
+	return s.tokens[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")]
+}
+
+func validate(collection string, body object) []object {
+	var failures []object
+	for _, field := range requiredFields[collection] {
+		if value, _ := body[field].(string); value == "" {
+			failures = append(failures, object{"field": field, "reason": "is required"})
+		}
+	}
+
+	return failures
+}
+
+func copyObject(source object) object {
+	copied := object{}
+	for key, value := range source {
+		copied[key] = value
+	}
+
+	return copied
+}
+
+func toInt(value interface{}) int {
+	switch v := value.(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	}
+
+	return 0
+}
+
+func toStrings(value interface{}) []string {
+	var strs []string
+	switch v := value.(type) {
+	case []string:
+		strs = append(strs, v...)
+	case []interface{}:
+		for _, item := range v {
+			if str, ok := item.(string); ok {
+				strs = append(strs, str)
+			}
+		}
+	}
+
+	return strs
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(payload)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, object{"error": message})
+}
@@ -0,0 +1,40 @@
+package placeos
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// sensitiveLogKeys are log field keys whose values are masked before they
+// reach TF_LOG output.
+var sensitiveLogKeys = []string{
+	"password",
+	"client_secret",
+	"api_key",
+	"token",
+	"access_token",
+	"refresh_token",
+	"settings_string",
+}
+
+// logContext returns ctx configured to mask sensitiveLogKeys on every tflog
+// call made with it.
+func logContext(ctx context.Context) context.Context {
+	return tflog.MaskFieldValuesWithFieldKeys(ctx, sensitiveLogKeys...)
+}
+
+// logFields flattens an API object into tflog fields keyed by its JSON
+// attribute names, so sensitive attributes can be masked by key.
+func logFields(object interface{}) map[string]interface{} {
+	fields := map[string]interface{}{}
+
+	payload, err := json.Marshal(object)
+	if err != nil {
+		return fields
+	}
+	json.Unmarshal(payload, &fields)
+
+	return fields
+}
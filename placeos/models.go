@@ -0,0 +1,119 @@
+package placeos
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/api"
+)
+
+// clientFromProviderData returns the client set up by the provider
+// Configure, data is nil until the provider has been configured.
+func clientFromProviderData(data interface{}, diags *diag.Diagnostics) *api.Client {
+	if data == nil {
+		return nil
+	}
+
+	client, ok := data.(*api.Client)
+	if !ok {
+		diags.AddError("Unexpected provider data", fmt.Sprintf("Expected *api.Client, got %T. Please report this issue to the provider developers.", data))
+		return nil
+	}
+
+	return client
+}
+
+// listStrings returns the elements of a list of strings, null and unknown
+// lists are empty.
+func listStrings(ctx context.Context, list types.List, diags *diag.Diagnostics) []string {
+	values := []string{}
+	if list.IsNull() || list.IsUnknown() {
+		return values
+	}
+	diags.Append(list.ElementsAs(ctx, &values, false)...)
+
+	return values
+}
+
+// stringList converts engine strings into a list value, a missing array is
+// an empty list.
+func stringList(ctx context.Context, values []string, diags *diag.Diagnostics) types.List {
+	if values == nil {
+		values = []string{}
+	}
+	list, d := types.ListValueFrom(ctx, types.StringType, values)
+	diags.Append(d...)
+
+	return list
+}
+
+// emptyStringList is the default of optional lists the engine always
+// returns.
+var emptyStringList = types.ListValueMust(types.StringType, []attr.Value{})
+
+// setStrings returns the elements of a set of strings, null and unknown
+// sets are empty.
+func setStrings(ctx context.Context, set types.Set, diags *diag.Diagnostics) []string {
+	values := []string{}
+	if set.IsNull() || set.IsUnknown() {
+		return values
+	}
+	diags.Append(set.ElementsAs(ctx, &values, false)...)
+
+	return values
+}
+
+// stringSet converts engine strings into a set value, a missing array is an
+// empty set.
+func stringSet(ctx context.Context, values []string, diags *diag.Diagnostics) types.Set {
+	if values == nil {
+		values = []string{}
+	}
+	set, d := types.SetValueFrom(ctx, types.StringType, values)
+	diags.Append(d...)
+
+	return set
+}
+
+// emptyStringSet is the default of optional sets the engine always
+// returns.
+var emptyStringSet = types.SetValueMust(types.StringType, []attr.Value{})
+
+// jsonString converts a JSON document returned by the engine into a string
+// value. prior is kept when it holds the same document so that formatting
+// and key order in the configuration do not show up as changes.
+func jsonString(prior types.String, raw []byte) types.String {
+	if !prior.IsNull() && !prior.IsUnknown() && jsonEqual(prior.ValueString(), string(raw)) {
+		return prior
+	}
+
+	return types.StringValue(compactJSON(raw))
+}
+
+// compactJSON returns raw without insignificant whitespace, or unchanged
+// when it is not valid JSON.
+func compactJSON(raw []byte) string {
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, raw); err != nil {
+		return string(raw)
+	}
+
+	return compact.String()
+}
+
+// jsonEqual reports whether a and b are the same JSON document.
+func jsonEqual(a string, b string) bool {
+	var left, right interface{}
+	if json.Unmarshal([]byte(a), &left) != nil || json.Unmarshal([]byte(b), &right) != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(left, right)
+}
@@ -3,85 +3,322 @@ package placeos
 import (
 	"context"
 	"fmt"
+	"os"
+	"strconv"
+	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/api"
 )
 
-func Provider() *schema.Provider {
-	return &schema.Provider{
-		Schema: map[string]*schema.Schema{
-			"username": &schema.Schema{
-				Type:        schema.TypeString,
-				Required:    true,
-				DefaultFunc: schema.EnvDefaultFunc("PLACEOS_USERNAME", ""),
-			},
+var _ provider.Provider = &placeosProvider{}
 
-			"password": &schema.Schema{
-				Type:        schema.TypeString,
-				Required:    true,
-				Sensitive:   true,
-				DefaultFunc: schema.EnvDefaultFunc("PLACEOS_PASSWORD", ""),
-			},
+type placeosProvider struct {
+	version string
+}
 
-			"host": &schema.Schema{
-				Type:        schema.TypeString,
-				Required:    true,
-				DefaultFunc: schema.EnvDefaultFunc("PLACEOS_HOST", ""),
-			},
+// placeosProviderModel maps the provider block. Null arguments fall back to
+// their PLACEOS_* environment variable, see newProviderClient.
+type placeosProviderModel struct {
+	Username          types.String `tfsdk:"username"`
+	Password          types.String `tfsdk:"password"`
+	ApiKey            types.String `tfsdk:"api_key"`
+	Token             types.String `tfsdk:"token"`
+	GrantType         types.String `tfsdk:"grant_type"`
+	Scope             types.String `tfsdk:"scope"`
+	MaxRetries        types.Int64  `tfsdk:"max_retries"`
+	RetryMaxWait      types.Int64  `tfsdk:"retry_max_wait"`
+	PageSize          types.Int64  `tfsdk:"page_size"`
+	Host              types.String `tfsdk:"host"`
+	ClientId          types.String `tfsdk:"client_id"`
+	ClientSecret      types.String `tfsdk:"client_secret"`
+	InsecureSsl       types.Bool   `tfsdk:"insecure_ssl"`
+	CACertificate     types.String `tfsdk:"ca_certificate"`
+	ClientCertificate types.String `tfsdk:"client_certificate"`
+	ClientKey         types.String `tfsdk:"client_key"`
+}
 
-			"client_id": &schema.Schema{
-				Type:         schema.TypeString,
-				Required:     true,
-				ValidateFunc: validation.StringIsNotEmpty,
-				// DefaultFunc: schema.EnvDefaultFunc("PLACEOS_CLIENT_ID", ""),
+// New returns the provider constructor, version is reported to Terraform
+// and set at build time.
+func New(version string) func() provider.Provider {
+	return func() provider.Provider {
+		return &placeosProvider{
+			version: version,
+		}
+	}
+}
+
+func (p *placeosProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "placeos"
+	resp.Version = p.version
+}
+
+func (p *placeosProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				Optional:    true,
+				Description: "Required for the `password` grant.",
 			},
-			"client_secret": &schema.Schema{
-				Type:        schema.TypeString,
-				Required:    true,
+			"password": schema.StringAttribute{
+				Optional:    true,
 				Sensitive:   true,
-				DefaultFunc: schema.EnvDefaultFunc("PLACEOS_CLIENT_SECRET", "secret"),
+				Description: "Required for the `password` grant.",
 			},
-
-			"insecure_ssl": &schema.Schema{
-				Type:        schema.TypeBool,
+			"api_key": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "PlaceOS API key sent in the `X-API-Key` header. When set, the OAuth arguments are ignored.",
+			},
+			"token": schema.StringAttribute{
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("PLACEOS_CLIENT_INSECURE_SSL", false),
+				Sensitive:   true,
+				Description: "Pre-issued OAuth bearer token. It is never refreshed. When set, the OAuth arguments are ignored.",
+			},
+			"grant_type": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{stringvalidator.OneOf(api.GrantTypePassword, api.GrantTypeClientCredentials)},
+				Description: "OAuth grant used to authenticate, either `password` or `client_credentials`. Defaults to `password`.",
+			},
+			"scope": schema.StringAttribute{
+				Optional:    true,
+				Description: "OAuth scope requested for the access token. Defaults to `public`.",
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.AtLeast(0)},
+				Description: "How many times a request failing with a transient error is retried. Defaults to `3`.",
+			},
+			"retry_max_wait": schema.Int64Attribute{
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+				Description: "Maximum number of seconds to wait between two retries. Defaults to `30`.",
+			},
+			"page_size": schema.Int64Attribute{
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+				Description: "Number of items requested per page when data sources list the engine. Defaults to `100`.",
+			},
+			"host": schema.StringAttribute{
+				Optional: true,
+			},
+			"client_id": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+				Description: "Required unless `api_key` or `token` is set.",
+			},
+			"client_secret": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+			},
+			"insecure_ssl": schema.BoolAttribute{
+				Optional: true,
+			},
+			"ca_certificate": schema.StringAttribute{
+				Optional:    true,
+				Description: "PEM encoded CA bundle used to verify the engine, as a file path or inline content.",
+			},
+			"client_certificate": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{stringvalidator.AlsoRequires(path.MatchRoot("client_key"))},
+				Description: "PEM encoded client certificate for mutual TLS, as a file path or inline content.",
+			},
+			"client_key": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Validators:  []validator.String{stringvalidator.AlsoRequires(path.MatchRoot("client_certificate"))},
+				Description: "PEM encoded private key matching `client_certificate`, as a file path or inline content.",
 			},
 		},
-		ResourcesMap: map[string]*schema.Resource{
-			"placeos_repository": resourceRepository(),
-			"placeos_driver":     resourceDriver(),
-			"placeos_setting":    resourceSetting(),
-			"placeos_module":     resourceModule(),
-			"placeos_zone":       resourceZone(),
-			"placeos_system":     resourceSystem(),
-		},
-		DataSourcesMap: map[string]*schema.Resource{
-			"placeos_repositories": dataSourceRepository(),
-		},
-		ConfigureContextFunc: providerConfigure,
 	}
 }
 
-func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	username := d.Get("username").(string)
-	password := d.Get("password").(string)
-	host := d.Get("host").(string)
-	insecureSsl := d.Get("insecure_ssl").(bool)
-	clientId := d.Get("client_id").(string)
-	clientSecret := d.Get("client_secret").(string)
-	// Warning or errors can be collected in a slice type
+func (p *placeosProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return []func() resource.Resource{
+		newRepositoryResource,
+		newDriverResource,
+		newSettingResource,
+		newModuleResource,
+		newZoneResource,
+		newSystemResource,
+		newSystemTriggerResource,
+		newTriggerResource,
+		newMetadataResource,
+	}
+}
 
-	fmt.Println("insecure value")
-	fmt.Println(clientId)
+func (p *placeosProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return []func() datasource.DataSource{
+		newRepositoriesDataSource,
+		newZonesDataSource,
+		newZoneDataSource,
+		newSystemsDataSource,
+		newSystemDataSource,
+		newDriversDataSource,
+		newModulesDataSource,
+	}
+}
 
-	fmt.Println("insecure value")
-	fmt.Println(insecureSsl)
+func (p *placeosProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	var config placeosProviderModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Host.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(path.Root("host"), "Unknown PlaceOS host", "The client cannot be created while host is unknown, set it to a static value or use PLACEOS_HOST.")
+		return
+	}
+
+	client, diags := newProviderClient(ctx, config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
+	resp.DataSourceData = client
+	resp.ResourceData = client
+}
+
+// newProviderClient builds a client for whichever authentication method the
+// provider configuration selects.
+func newProviderClient(ctx context.Context, config placeosProviderModel) (*api.Client, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
-	client := NewBasicAuthClient(username, password, host, insecureSsl, clientId, clientSecret)
+	host := envString(config.Host, "PLACEOS_HOST", "")
+	apiKey := envString(config.ApiKey, "PLACEOS_API_KEY", "")
+	token := envString(config.Token, "PLACEOS_TOKEN", "")
+	grantType := envString(config.GrantType, "PLACEOS_GRANT_TYPE", api.GrantTypePassword)
+	clientId := envString(config.ClientId, "PLACEOS_CLIENT_ID", "")
+	insecureSsl := envBool(config.InsecureSsl, "PLACEOS_CLIENT_INSECURE_SSL", false, &diags)
+	maxRetries := envInt64(config.MaxRetries, "PLACEOS_MAX_RETRIES", api.DefaultMaxRetries, &diags)
+	retryMaxWait := envInt64(config.RetryMaxWait, "PLACEOS_RETRY_MAX_WAIT", int64(api.DefaultRetryMaxWait/time.Second), &diags)
+	pageSize := envInt64(config.PageSize, "PLACEOS_PAGE_SIZE", api.DefaultPageSize, &diags)
+	tlsOptions := api.TLSOptions{
+		InsecureSsl:       insecureSsl,
+		CACertificate:     envString(config.CACertificate, "PLACEOS_CA_CERTIFICATE", ""),
+		ClientCertificate: envString(config.ClientCertificate, "PLACEOS_CLIENT_CERTIFICATE", ""),
+		ClientKey:         envString(config.ClientKey, "PLACEOS_CLIENT_KEY", ""),
+	}
+	if host == "" {
+		diags.AddAttributeError(path.Root("host"), "Missing host", "host or PLACEOS_HOST must be set to the PlaceOS engine URL.")
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	ctx = logContext(ctx)
+	tflog.Debug(ctx, "configuring PlaceOS client", map[string]interface{}{
+		"host":         host,
+		"client_id":    clientId,
+		"grant_type":   grantType,
+		"insecure_ssl": insecureSsl,
+	})
+
+	var client *api.Client
+	var err error
+	switch {
+	case apiKey != "":
+		client, err = api.NewApiKeyClient(host, apiKey, tlsOptions)
+	case token != "":
+		client, err = api.NewTokenClient(host, token, tlsOptions)
+	default:
+		username := envString(config.Username, "PLACEOS_USERNAME", "")
+		password := envString(config.Password, "PLACEOS_PASSWORD", "")
+		clientSecret := envString(config.ClientSecret, "PLACEOS_CLIENT_SECRET", "secret")
+		scope := envString(config.Scope, "PLACEOS_SCOPE", "public")
+
+		required := []string{"client_id"}
+		values := map[string]string{"client_id": clientId, "username": username, "password": password}
+		if grantType == api.GrantTypePassword {
+			required = append(required, "username", "password")
+		}
+		for _, argument := range required {
+			if values[argument] == "" {
+				diags.AddAttributeError(
+					path.Root(argument),
+					fmt.Sprintf("Missing %s", argument),
+					fmt.Sprintf("%s is required for the %s grant when neither api_key nor token is set.", argument, grantType),
+				)
+			}
+		}
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		if grantType == api.GrantTypeClientCredentials {
+			client, err = api.NewClientCredentialsClient(ctx, host, clientId, clientSecret, scope, tlsOptions)
+		} else {
+			client, err = api.NewBasicAuthClient(ctx, username, password, host, clientId, clientSecret, scope, tlsOptions)
+		}
+	}
+	if err != nil {
+		diags.AddError("Unable to create the PlaceOS client", err.Error())
+		return nil, diags
+	}
+
+	client.MaxRetries = int(maxRetries)
+	client.RetryMaxWait = time.Duration(retryMaxWait) * time.Second
+	client.PageSize = int(pageSize)
+
 	return client, diags
 }
+
+// envString returns the configured value, falling back to the environment
+// variable key and then to fallback when the argument is null.
+func envString(value types.String, key string, fallback string) string {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueString()
+	}
+	if env, ok := os.LookupEnv(key); ok {
+		return env
+	}
+
+	return fallback
+}
+
+func envInt64(value types.Int64, key string, fallback int64, diags *diag.Diagnostics) int64 {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueInt64()
+	}
+	env, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	parsed, err := strconv.ParseInt(env, 10, 64)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Invalid %s", key), fmt.Sprintf("%s must be an integer: %s", key, err))
+		return fallback
+	}
+
+	return parsed
+}
+
+func envBool(value types.Bool, key string, fallback bool, diags *diag.Diagnostics) bool {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueBool()
+	}
+	env, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	parsed, err := strconv.ParseBool(env)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Invalid %s", key), fmt.Sprintf("%s must be a boolean: %s", key, err))
+		return fallback
+	}
+
+	return parsed
+}
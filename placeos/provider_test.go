@@ -0,0 +1,164 @@
+package placeos
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/api"
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/internal/fakeengine"
+)
+
+var testProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"placeos": providerserver.NewProtocol6WithError(New("test")()),
+}
+
+func TestProvider(t *testing.T) {
+	server, err := testProtoV6ProviderFactories["placeos"]()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	resp, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Errorf("%s: %s", d.Summary, d.Detail)
+		}
+	}
+}
+
+// testUnitPreCheck skips terraform-driven unit tests when no terraform CLI
+// is available to run them.
+func testUnitPreCheck(t *testing.T) {
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") != "" {
+		return
+	}
+	if _, err := exec.LookPath("terraform"); err != nil {
+		t.Skip("terraform CLI not found, set TF_ACC_TERRAFORM_PATH to run this test")
+	}
+}
+
+// testFakeEngine starts a fake engine for the duration of the test.
+func testFakeEngine(t *testing.T) *fakeengine.Server {
+	engine := fakeengine.New()
+	t.Cleanup(engine.Close)
+
+	return engine
+}
+
+// testFakeProviderConfig points the provider at the fake engine, it is
+// prepended to every unit test configuration.
+func testFakeProviderConfig(engine *fakeengine.Server) string {
+	return fmt.Sprintf(`
+provider "placeos" {
+  host        = %q
+  api_key     = %q
+  max_retries = 0
+}
+`, engine.URL, fakeengine.ApiKey)
+}
+
+// testAccPreCheck validates the environment acceptance tests need, the
+// provider itself is configured from the same PLACEOS_* variables.
+func testAccPreCheck(t *testing.T) {
+	if os.Getenv("PLACEOS_HOST") == "" {
+		t.Fatal("PLACEOS_HOST must be set for acceptance tests")
+	}
+	if os.Getenv("PLACEOS_API_KEY") == "" && os.Getenv("PLACEOS_TOKEN") == "" && os.Getenv("PLACEOS_CLIENT_ID") == "" {
+		t.Fatal("PLACEOS_API_KEY, PLACEOS_TOKEN or PLACEOS_CLIENT_ID must be set for acceptance tests")
+	}
+}
+
+// testAccClient configures a provider from the environment and returns its
+// client, for checks that talk to the engine directly.
+func testAccClient() (*api.Client, error) {
+	client, diags := newProviderClient(context.Background(), placeosProviderModel{})
+	if diags.HasError() {
+		return nil, fmt.Errorf("configuring provider: %v", diags)
+	}
+
+	return client, nil
+}
+
+// testAccCheckDestroy verifies every resourceType left in state is gone
+// from the engine, get must return the engine error for the given id.
+func testAccCheckDestroy(resourceType string, get func(ctx context.Context, c *api.Client, id string) error) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		c, err := testAccClient()
+		if err != nil {
+			return err
+		}
+
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+
+			err := get(context.Background(), c, rs.Primary.ID)
+			if err == nil {
+				return fmt.Errorf("%s %s still exists", resourceType, rs.Primary.ID)
+			}
+			if !api.IsNotFound(err) {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// testAccStoreID records the id of a resource so a later step can tell
+// whether it was replaced.
+func testAccStoreID(name string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("%s not found in state", name)
+		}
+		*id = rs.Primary.ID
+
+		return nil
+	}
+}
+
+// testAccCheckIDChanged verifies a ForceNew change replaced the resource
+// stored by testAccStoreID.
+func testAccCheckIDChanged(name string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("%s not found in state", name)
+		}
+		if rs.Primary.ID == *id {
+			return fmt.Errorf("%s was updated in place, expected it to be replaced", name)
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckIDUnchanged verifies the resource stored by testAccStoreID was
+// updated in place.
+func testAccCheckIDUnchanged(name string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("%s not found in state", name)
+		}
+		if rs.Primary.ID != *id {
+			return fmt.Errorf("%s was replaced, expected it to be updated in place", name)
+		}
+
+		return nil
+	}
+}
@@ -2,237 +2,273 @@ package placeos
 
 import (
 	"context"
-	"encoding/json"
-	"os"
+	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/api"
 )
 
-func resourceDriver() *schema.Resource {
-	return &schema.Resource{
-		CreateContext: resourceDriverCreate,
-		ReadContext:   resourceDriverRead,
-		UpdateContext: resourceDriverUpdate,
-		DeleteContext: resourceDriverDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
-		Schema: map[string]*schema.Schema{
-			"name": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+var (
+	_ resource.Resource                = &driverResource{}
+	_ resource.ResourceWithConfigure   = &driverResource{}
+	_ resource.ResourceWithImportState = &driverResource{}
+)
+
+type driverResource struct {
+	client *api.Client
+}
+
+type driverResourceModel struct {
+	Id               types.String   `tfsdk:"id"`
+	Name             types.String   `tfsdk:"name"`
+	FileName         types.String   `tfsdk:"file_name"`
+	DefaultUri       types.String   `tfsdk:"default_uri"`
+	ModuleName       types.String   `tfsdk:"module_name"`
+	Description      types.String   `tfsdk:"description"`
+	RepositoryId     types.String   `tfsdk:"repository_id"`
+	Commit           types.String   `tfsdk:"commit"`
+	Role             types.Int64    `tfsdk:"role"`
+	IgnoredConnected types.Bool     `tfsdk:"ignored_connected"`
+	CreatedAt        types.Int64    `tfsdk:"created_at"`
+	UpdatedAt        types.Int64    `tfsdk:"updated_at"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
+}
+
+func newDriverResource() resource.Resource {
+	return &driverResource{}
+}
+
+func (r *driverResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_driver"
+}
+
+func (r *driverResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
-			"file_name": &schema.Schema{
-				Type:     schema.TypeString,
+			"name": schema.StringAttribute{
 				Required: true,
 			},
-			"default_uri": &schema.Schema{
-				Type:     schema.TypeString,
+			"file_name": schema.StringAttribute{
 				Required: true,
 			},
-			"module_name": &schema.Schema{
-				Type:     schema.TypeString,
+			"default_uri": schema.StringAttribute{
 				Required: true,
 			},
-			"description": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
+			"module_name": schema.StringAttribute{
+				Required: true,
 			},
-			"repository_id": &schema.Schema{
-				Type:     schema.TypeString,
+			"description": schema.StringAttribute{
 				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
 			},
-			"commit": &schema.Schema{
-				Type:     schema.TypeString,
+			"repository_id": schema.StringAttribute{
 				Optional: true,
 				Computed: true,
+				Default:  stringdefault.StaticString(""),
 			},
-			"role": &schema.Schema{
-				Type:     schema.TypeInt,
-				Optional: true,
+			"commit": schema.StringAttribute{
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+				Description:   "Commit the driver is compiled from. Defaults to the latest commit of `file_name` in the repository.",
 			},
-			"id": &schema.Schema{
-				Type:     schema.TypeString,
+			"role": schema.Int64Attribute{
+				Optional: true,
 				Computed: true,
+				Default:  int64default.StaticInt64(0),
 			},
-			"created_at": &schema.Schema{
-				Type:     schema.TypeInt,
+			"ignored_connected": schema.BoolAttribute{
+				Optional: true,
 				Computed: true,
+				Default:  booldefault.StaticBool(false),
 			},
-			"ignored_connected": &schema.Schema{
-				Type:     schema.TypeBool,
-				Computed: true,
-				Optional: true,
+			"created_at": schema.Int64Attribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
 			},
-			"updated_at": &schema.Schema{
-				Type:     schema.TypeInt,
+			"updated_at": schema.Int64Attribute{
 				Computed: true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
-func resourceDriverCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	// Warning or errors can be collected in a slice type
-	c := m.(*Client)
+func (r *driverResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = clientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
 
-	name := d.Get("name").(string)
-	description := d.Get("description").(string)
-	file_name := d.Get("file_name").(string)
-	default_uri := d.Get("default_uri").(string)
-	role := d.Get("role").(int)
-	module_name := d.Get("module_name").(string)
-	repository_id := d.Get("repository_id").(string)
-	ignore_connected := d.Get("ignored_connected").(bool)
-	commit, err := c.getLastCommitHash(repository_id, file_name)
+func (r *driverResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan driverResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	if err != nil {
-		return diag.FromErr(err)
+	createTimeout, diags := plan.Timeouts.Create(ctx, 15*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
-	driver, err := c.createDriver(name, description, file_name, default_uri, module_name, repository_id, commit, role, ignore_connected)
+	driver := &api.Driver{}
+	plan.toAPI(driver)
 
+	if plan.Commit.IsUnknown() || plan.Commit.IsNull() {
+		commit, err := r.client.Repositories.LatestCommit(ctx, driver.RepositoryId, driver.FileName)
+		if err != nil {
+			resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+			return
+		}
+		driver.Commit = commit
+	}
+
+	driver, err := r.client.Drivers.Create(ctx, driver)
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+		return
 	}
 
-	d.SetId(driver.Id)
-	return resourceDriverRead(ctx, d, m)
+	plan.fromAPI(driver)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
-// add a resource driver read function with diagnostic tool for each field
-func resourceDriverRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	// Warning or errors can be collected in a slice type
-	c := m.(*Client)
-	var diags diag.Diagnostics
-	id := d.Get("id").(string)
-	driver, err := c.getDriver(id)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	d.Set("name", driver.Name)
-	d.Set("file_name", driver.FileName)
-	d.Set("default_uri", driver.DefaultUri)
-	d.Set("module_name", driver.ModuleName)
-	d.Set("description", driver.Description)
-	d.Set("repository_id", driver.RepositoryId)
-	d.Set("commit", driver.Commit)
-	d.Set("role", driver.Role)
-	d.Set("created_at", driver.CreatedAt)
-	d.Set("ignored_connected", driver.IgnoredConnected)
-	d.Set("updated_at", driver.UpdatedAt)
-
-	return diags
-}
+func (r *driverResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state driverResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-// add a resource driver update function with diagnostic tool for each field
-func resourceDriverUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	// Warning or errors can be collected in a slice type
-	c := m.(*Client)
-	id := d.Get("id").(string)
-	driver, err := c.getDriver(id)
-	if err != nil {
-		return diag.FromErr(err)
+	driver, err := r.client.Drivers.Get(ctx, state.Id.ValueString())
+	if api.IsNotFound(err) {
+		tflog.Warn(ctx, "driver not found, removing it from state", map[string]interface{}{"id": state.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
 	}
-	file, err := os.Create("/tmp/driver.json")
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+		return
 	}
 
-	text, err := json.Marshal(driver)
+	state.fromAPI(driver)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
 
-	if err != nil {
-		return diag.FromErr(err)
+func (r *driverResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan driverResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	file.Write(text)
-	file.Close()
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 15*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
+	driver, err := r.client.Drivers.Get(ctx, plan.Id.ValueString())
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+		return
 	}
-	// check each field has change and replace it if it has
+	ctx = logContext(ctx)
+	tflog.Debug(ctx, "read driver before update", logFields(driver))
 
-	if d.HasChange("created_at") {
-		driver.CreatedAt = d.Get("created_at").(int64)
-	}
-	if d.HasChange("name") {
-		driver.Name = d.Get("name").(string)
-	}
-	if d.HasChange("file_name") {
-		driver.FileName = d.Get("file_name").(string)
-	}
-	if d.HasChange("default_uri") {
-		driver.DefaultUri = d.Get("default_uri").(string)
-	}
-	if d.HasChange("module_name") {
-		driver.ModuleName = d.Get("module_name").(string)
-	}
-	if d.HasChange("description") {
-		driver.Description = d.Get("description").(string)
-	}
-	if d.HasChange("repository_id") {
-		driver.RepositoryId = d.Get("repository_id").(string)
-	}
-	if d.HasChange("commit") {
-		driver.Commit, err = c.getLastCommitHash(driver.RepositoryId, driver.FileName)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-	}
-	if d.HasChange("role") {
-		driver.Role = d.Get("role").(int)
-	}
-	if d.HasChange("ignored_connected") {
-		driver.IgnoredConnected = d.Get("ignored_connected").(bool)
-	}
+	plan.toAPI(driver)
 
-	file2, err := os.Create("/tmp/driver2.json")
-	if err != nil {
-		return diag.FromErr(err)
-	}
+	tflog.Debug(ctx, "updating driver", logFields(driver))
 
-	tex2, err := json.Marshal(driver)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	file2.Write(tex2)
-	file2.Close()
-
-	err = c.updateDriver(
-		driver.Id,
-		driver.Name,
-		driver.Description,
-		driver.FileName,
-		driver.DefaultUri,
-		driver.ModuleName,
-		driver.RepositoryId,
-		driver.Commit,
-		driver.Role,
-		driver.IgnoredConnected,
-		driver.CreatedAt,
-		driver.UpdatedAt,
-	)
+	driver, err = r.client.Drivers.Update(ctx, driver)
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+		return
 	}
 
-	return resourceDriverRead(ctx, d, m)
+	plan.fromAPI(driver)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
-// add a resource driver delete function with diagnostic as return
-func resourceDriverDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*Client)
-	var diags diag.Diagnostics
+func (r *driverResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state driverResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	id := d.Get("id").(string)
-	err := c.deleteDriver(id)
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
 
-	if err != nil {
-		return diag.FromErr(err)
+	err := r.client.Drivers.Delete(ctx, state.Id.ValueString())
+	if err != nil && !api.IsNotFound(err) {
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
 	}
+}
+
+func (r *driverResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// toAPI copies the planned attributes onto driver. An unknown commit is
+// left as it is, the caller resolves it.
+func (m *driverResourceModel) toAPI(driver *api.Driver) {
+	driver.Name = m.Name.ValueString()
+	driver.FileName = m.FileName.ValueString()
+	driver.DefaultUri = m.DefaultUri.ValueString()
+	driver.ModuleName = m.ModuleName.ValueString()
+	driver.Description = m.Description.ValueString()
+	driver.RepositoryId = m.RepositoryId.ValueString()
+	if !m.Commit.IsUnknown() && !m.Commit.IsNull() {
+		driver.Commit = m.Commit.ValueString()
+	}
+	driver.Role = int(m.Role.ValueInt64())
+	driver.IgnoredConnected = m.IgnoredConnected.ValueBool()
+}
 
-	return diags
+func (m *driverResourceModel) fromAPI(driver *api.Driver) {
+	m.Id = types.StringValue(driver.Id)
+	m.Name = types.StringValue(driver.Name)
+	m.FileName = types.StringValue(driver.FileName)
+	m.DefaultUri = types.StringValue(driver.DefaultUri)
+	m.ModuleName = types.StringValue(driver.ModuleName)
+	m.Description = types.StringValue(driver.Description)
+	m.RepositoryId = types.StringValue(driver.RepositoryId)
+	m.Commit = types.StringValue(driver.Commit)
+	m.Role = types.Int64Value(int64(driver.Role))
+	m.IgnoredConnected = types.BoolValue(driver.IgnoredConnected)
+	m.CreatedAt = types.Int64Value(driver.CreatedAt)
+	m.UpdatedAt = types.Int64Value(driver.UpdatedAt)
 }
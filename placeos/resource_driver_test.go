@@ -0,0 +1,113 @@
+package placeos
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/api"
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/internal/fakeengine"
+)
+
+func TestUnitDriver_basic(t *testing.T) {
+	engine := testFakeEngine(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testFakeCheckDestroy(engine, "drivers", "placeos_driver"),
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeProviderConfig(engine) + testDriverConfig("Lutron"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("placeos_driver.test", "id"),
+					resource.TestCheckResourceAttr("placeos_driver.test", "name", "Lutron"),
+					resource.TestCheckResourceAttr("placeos_driver.test", "commit", fakeengine.CommitHash),
+				),
+			},
+			{
+				Config: testFakeProviderConfig(engine) + testDriverConfig("Lutron Lighting"),
+				Check:  resource.TestCheckResourceAttr("placeos_driver.test", "name", "Lutron Lighting"),
+			},
+			{
+				ResourceName:      "placeos_driver.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testDriverConfig(name string) string {
+	return testRepositoryConfig("Drivers", "main") + fmt.Sprintf(`
+resource "placeos_driver" "test" {
+  name          = %q
+  file_name     = "drivers/lutron/lighting.cr"
+  default_uri   = "ssh://192.168.0.10:23"
+  module_name   = "Lighting"
+  repository_id = placeos_repository.test.id
+  role          = 1
+}
+`, name)
+}
+
+func TestAccDriver_basic(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-driver")
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDriverDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDriverConfig(name, "Bookings"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccStoreID("placeos_driver.test", &id),
+					resource.TestCheckResourceAttr("placeos_driver.test", "name", name),
+					resource.TestCheckResourceAttrSet("placeos_driver.test", "commit"),
+				),
+			},
+			{
+				Config: testAccDriverConfig(name+"-renamed", "Bookings2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDUnchanged("placeos_driver.test", &id),
+					resource.TestCheckResourceAttr("placeos_driver.test", "name", name+"-renamed"),
+					resource.TestCheckResourceAttr("placeos_driver.test", "module_name", "Bookings2"),
+				),
+			},
+			{
+				ResourceName:      "placeos_driver.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testAccDriverFile is a driver of testAccDriversUri the engine can compile.
+const testAccDriverFile = "drivers/place/bookings.cr"
+
+func testAccCheckDriverDestroy(s *terraform.State) error {
+	return testAccCheckDestroy("placeos_driver", func(ctx context.Context, c *api.Client, id string) error {
+		_, err := c.Drivers.Get(ctx, id)
+		return err
+	})(s)
+}
+
+func testAccDriverConfig(name string, moduleName string) string {
+	return testAccRepositoryConfig(name, testAccDriversUri) + fmt.Sprintf(`
+resource "placeos_driver" "test" {
+  name          = %q
+  file_name     = %q
+  default_uri   = "https://placeos.example.com"
+  module_name   = %q
+  repository_id = placeos_repository.test.id
+  role          = 2
+}
+`, name, testAccDriverFile, moduleName)
+}
@@ -0,0 +1,240 @@
+package placeos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/api"
+)
+
+var (
+	_ resource.Resource                = &metadataResource{}
+	_ resource.ResourceWithConfigure   = &metadataResource{}
+	_ resource.ResourceWithImportState = &metadataResource{}
+)
+
+type metadataResource struct {
+	client *api.Client
+}
+
+type metadataResourceModel struct {
+	Id          types.String         `tfsdk:"id"`
+	ParentId    types.String         `tfsdk:"parent_id"`
+	Name        types.String         `tfsdk:"name"`
+	Details     jsontypes.Normalized `tfsdk:"details"`
+	Description types.String         `tfsdk:"description"`
+	Editors     types.Set            `tfsdk:"editors"`
+	UpdatedAt   types.Int64          `tfsdk:"updated_at"`
+	Timeouts    timeouts.Value       `tfsdk:"timeouts"`
+}
+
+func newMetadataResource() resource.Resource {
+	return &metadataResource{}
+}
+
+func (r *metadataResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_metadata"
+}
+
+func (r *metadataResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A named JSON document attached to a zone, system or user, e.g. the map or desk layout of a level. Imported as `<parent_id>/<name>`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"parent_id": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Description:   "ID of the zone, system or user the metadata belongs to.",
+			},
+			"name": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"details": schema.StringAttribute{
+				CustomType:  jsontypes.NormalizedType{},
+				Required:    true,
+				Description: "JSON document, e.g. built with jsonencode. Changes in formatting or key order made by the engine are not reported as drift.",
+			},
+			"description": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
+			},
+			"editors": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Default:     setdefault.StaticValue(emptyStringSet),
+				Description: "Groups allowed to edit the metadata, besides admins.",
+			},
+			"updated_at": schema.Int64Attribute{
+				Computed: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *metadataResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = clientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (r *metadataResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan metadataResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	r.put(ctx, &plan, resp.State.Schema, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *metadataResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state metadataResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	metadata, err := r.client.Metadata.Get(ctx, state.ParentId.ValueString(), state.Name.ValueString())
+	if api.IsNotFound(err) {
+		tflog.Warn(ctx, "metadata not found, removing it from state", map[string]interface{}{"id": state.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+		return
+	}
+
+	state.fromAPI(ctx, metadata, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *metadataResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan metadataResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	r.put(ctx, &plan, resp.State.Schema, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// put saves the planned metadata, the engine creates or replaces it by
+// name.
+func (r *metadataResource) put(ctx context.Context, plan *metadataResourceModel, schema attributeSchema, diags *diag.Diagnostics) {
+	metadata := &api.Metadata{
+		Name:        plan.Name.ValueString(),
+		Description: plan.Description.ValueString(),
+		Details:     json.RawMessage(plan.Details.ValueString()),
+		ParentId:    plan.ParentId.ValueString(),
+		Editors:     setStrings(ctx, plan.Editors, diags),
+	}
+	if diags.HasError() {
+		return
+	}
+
+	metadata, err := r.client.Metadata.Put(ctx, plan.ParentId.ValueString(), metadata)
+	if err != nil {
+		diags.Append(diagnosticsFromErr(ctx, err, schema)...)
+		return
+	}
+
+	plan.fromAPI(ctx, metadata, diags)
+}
+
+func (r *metadataResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state metadataResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.client.Metadata.Delete(ctx, state.ParentId.ValueString(), state.Name.ValueString())
+	if err != nil && !api.IsNotFound(err) {
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+	}
+}
+
+// ImportState takes "<parent_id>/<name>", metadata has no id of its own.
+func (r *metadataResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parentId, name, ok := strings.Cut(req.ID, "/")
+	if !ok || parentId == "" || name == "" {
+		resp.Diagnostics.AddError("Invalid import ID", fmt.Sprintf("Expected <parent_id>/<name>, got %q.", req.ID))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("parent_id"), parentId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+}
+
+// fromAPI sets the model from metadata, details that only differ in
+// formatting or key order are kept as planned by the normalised JSON type.
+func (m *metadataResourceModel) fromAPI(ctx context.Context, metadata *api.Metadata, diags *diag.Diagnostics) {
+	m.Id = types.StringValue(metadata.ParentId + "/" + metadata.Name)
+	m.ParentId = types.StringValue(metadata.ParentId)
+	m.Name = types.StringValue(metadata.Name)
+	m.Details = jsontypes.NewNormalizedValue(compactJSON(metadata.Details))
+	m.Description = types.StringValue(metadata.Description)
+	m.Editors = stringSet(ctx, metadata.Editors, diags)
+	m.UpdatedAt = types.Int64Value(metadata.UpdatedAt)
+}
@@ -0,0 +1,226 @@
+package placeos
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/api"
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/internal/fakeengine"
+)
+
+func TestUnitMetadata_basic(t *testing.T) {
+	engine := testFakeEngine(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testFakeCheckMetadataDestroy(engine),
+		Steps: []resource.TestStep{
+			{
+				// the engine returns the keys sorted and without spaces,
+				// which must not show as a change
+				Config: testFakeProviderConfig(engine) + testMetadataConfig(`<<EOT
+{ "zoom": 2, "map": "level-1.svg" }
+EOT`, `[]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("placeos_metadata.test", "parent_id", "placeos_zone.test", "id"),
+					resource.TestCheckResourceAttr("placeos_metadata.test", "name", "map"),
+					resource.TestCheckResourceAttr("placeos_metadata.test", "details", "{ \"zoom\": 2, \"map\": \"level-1.svg\" }\n"),
+					resource.TestCheckResourceAttr("placeos_metadata.test", "editors.#", "0"),
+					testFakeCheckMetadata(engine, "map", "level-1.svg"),
+				),
+			},
+			{
+				Config: testFakeProviderConfig(engine) + testMetadataConfig(`jsonencode({ map = "level-2.svg", zoom = 2 })`, `["facilities", "admin"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("placeos_metadata.test", "details", `{"map":"level-2.svg","zoom":2}`),
+					resource.TestCheckResourceAttr("placeos_metadata.test", "editors.#", "2"),
+					resource.TestCheckTypeSetElemAttr("placeos_metadata.test", "editors.*", "facilities"),
+					testFakeCheckMetadata(engine, "map", "level-2.svg"),
+				),
+			},
+			{
+				ResourceName:      "placeos_metadata.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestUnitMetadata_detailsFormatting(t *testing.T) {
+	engine := testFakeEngine(t)
+	config := testFakeProviderConfig(engine) + testMetadataConfig(`<<EOT
+{
+  "zoom" :   2,
+  "map":  "level-1.svg",
+  "layers": { "rooms": true,  "desks": false }
+}
+EOT`, `[]`)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testFakeCheckMetadataDestroy(engine),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  testFakeCheckMetadata(engine, "map", "level-1.svg"),
+			},
+			{
+				// the engine returns the keys sorted and compacted
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				RefreshState: true,
+				Check:        resource.TestCheckResourceAttr("placeos_metadata.test", "details", "{\n  \"zoom\" :   2,\n  \"map\":  \"level-1.svg\",\n  \"layers\": { \"rooms\": true,  \"desks\": false }\n}\n"),
+			},
+		},
+	})
+}
+
+func TestUnitMetadata_system(t *testing.T) {
+	engine := testFakeEngine(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testFakeCheckMetadataDestroy(engine),
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeProviderConfig(engine) + testSystemConfig("Meeting room 1", 8) + `
+resource "placeos_metadata" "test" {
+  parent_id   = placeos_system.test.id
+  name        = "bookings"
+  description = "Booking rules"
+  details     = jsonencode({ max_duration = 120 })
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("placeos_metadata.test", "parent_id", "placeos_system.test", "id"),
+					resource.TestCheckResourceAttr("placeos_metadata.test", "description", "Booking rules"),
+				),
+			},
+		},
+	})
+}
+
+func testMetadataConfig(details string, editors string) string {
+	return testZoneConfig("Level 1", 10) + fmt.Sprintf(`
+resource "placeos_metadata" "test" {
+  parent_id = placeos_zone.test.id
+  name      = "map"
+  details   = %s
+  editors   = %s
+}
+`, details, editors)
+}
+
+// testFakeCheckMetadata checks the map key of the details stored by the
+// engine.
+func testFakeCheckMetadata(engine *fakeengine.Server, name string, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources["placeos_metadata.test"]
+		if !ok {
+			return fmt.Errorf("placeos_metadata.test not found in state")
+		}
+
+		metadata, ok := engine.Metadata(rs.Primary.Attributes["parent_id"], name)
+		if !ok {
+			return fmt.Errorf("metadata %s not stored by the engine", name)
+		}
+		details, _ := metadata["details"].(map[string]interface{})
+		if details["map"] != expected {
+			return fmt.Errorf("expected map %q, got %v", expected, details["map"])
+		}
+
+		return nil
+	}
+}
+
+func testFakeCheckMetadataDestroy(engine *fakeengine.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "placeos_metadata" {
+				continue
+			}
+			if _, ok := engine.Metadata(rs.Primary.Attributes["parent_id"], rs.Primary.Attributes["name"]); ok {
+				return fmt.Errorf("placeos_metadata %s still exists", rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
+
+func TestAccMetadata_basic(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-metadata")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMetadataDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMetadataConfig(name, "level-1.svg"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("placeos_metadata.test", "name", name),
+					resource.TestCheckResourceAttr("placeos_metadata.test", "details", `{"map":"level-1.svg","zoom":2}`),
+				),
+			},
+			{
+				Config: testAccMetadataConfig(name, "level-2.svg"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("placeos_metadata.test", "details", `{"map":"level-2.svg","zoom":2}`),
+				),
+			},
+			{
+				ResourceName:      "placeos_metadata.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testAccCheckMetadataDestroy looks up metadata by parent and name, it has
+// no id of its own.
+func testAccCheckMetadataDestroy(s *terraform.State) error {
+	c, err := testAccClient()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "placeos_metadata" {
+			continue
+		}
+
+		_, err := c.Metadata.Get(context.Background(), rs.Primary.Attributes["parent_id"], rs.Primary.Attributes["name"])
+		if err == nil {
+			return fmt.Errorf("placeos_metadata %s still exists", rs.Primary.ID)
+		}
+		if !api.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccMetadataConfig(name string, mapFile string) string {
+	return testAccZoneConfig(name, 10, 1) + fmt.Sprintf(`
+resource "placeos_metadata" "test" {
+  parent_id   = placeos_zone.test.id
+  name        = %q
+  description = "Created by the terraform acceptance tests"
+  details     = jsonencode({ map = %q, zoom = 2 })
+}
+`, name, mapFile)
+}
@@ -2,224 +2,283 @@ package placeos
 
 import (
 	"context"
+	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/api"
 )
 
-func resourceModule() *schema.Resource {
-	return &schema.Resource{
-		CreateContext: resourceModuleCreate,
-		ReadContext:   resourceModuleRead,
-		UpdateContext: resourceModuleUpdate,
-		DeleteContext: resourceModuleDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+var (
+	_ resource.Resource                = &moduleResource{}
+	_ resource.ResourceWithConfigure   = &moduleResource{}
+	_ resource.ResourceWithImportState = &moduleResource{}
+)
 
-		Schema: map[string]*schema.Schema{
-			"custom_name": {
-				Type:     schema.TypeString,
-				Required: true,
+type moduleResource struct {
+	client *api.Client
+}
+
+type moduleResourceModel struct {
+	Id              types.String   `tfsdk:"id"`
+	CustomName      types.String   `tfsdk:"custom_name"`
+	DriverId        types.String   `tfsdk:"driver_id"`
+	Uri             types.String   `tfsdk:"uri"`
+	Notes           types.String   `tfsdk:"notes"`
+	Ip              types.String   `tfsdk:"ip"`
+	Port            types.Int64    `tfsdk:"port"`
+	Makebreak       types.Bool     `tfsdk:"makebreak"`
+	IgnoreConnected types.Bool     `tfsdk:"ignore_connected"`
+	IgnoreStartStop types.Bool     `tfsdk:"ignore_starstop"`
+	Tls             types.Bool     `tfsdk:"tls"`
+	Udp             types.Bool     `tfsdk:"udp"`
+	CreatedAt       types.Int64    `tfsdk:"created_at"`
+	UpdatedAt       types.Int64    `tfsdk:"updated_at"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+}
+
+func newModuleResource() resource.Resource {
+	return &moduleResource{}
+}
+
+func (r *moduleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_module"
+}
+
+func (r *moduleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
-			"driver_id": {
-				Type:     schema.TypeString,
+			"custom_name": schema.StringAttribute{
 				Required: true,
-				ForceNew: true,
 			},
-			"uri": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "",
+			"driver_id": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
 			},
-			"notes": {
-				Type:     schema.TypeString,
+			"uri": schema.StringAttribute{
 				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
 			},
-			"ip": {
-				Type:     schema.TypeString,
+			"notes": schema.StringAttribute{
 				Optional: true,
-				Default:  "",
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
 			},
-			"port": {
-				Type:     schema.TypeInt,
+			"ip": schema.StringAttribute{
 				Optional: true,
-				Default:  0,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
 			},
-			"makebreak": {
-				Type:     schema.TypeBool,
+			"port": schema.Int64Attribute{
 				Optional: true,
-				Default:  false,
+				Computed: true,
+				Default:  int64default.StaticInt64(0),
 			},
-			"ignore_connected": {
-				Type:     schema.TypeBool,
+			"makebreak": schema.BoolAttribute{
 				Optional: true,
-				Default:  false,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
 			},
-			"ignore_starstop": {
-				Type:     schema.TypeBool,
+			"ignore_connected": schema.BoolAttribute{
 				Optional: true,
-				Default:  false,
-			},
-			"tls": {
-				Type:     schema.TypeBool,
 				Computed: true,
+				Default:  booldefault.StaticBool(false),
 			},
-			"udp": {
-				Type:     schema.TypeBool,
+			"ignore_starstop": schema.BoolAttribute{
+				Optional: true,
 				Computed: true,
+				Default:  booldefault.StaticBool(false),
 			},
-			"id": {
-				Type:     schema.TypeString,
-				Computed: true,
+			"tls": schema.BoolAttribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
 			},
-			"created_at": {
-				Type:     schema.TypeInt,
-				Computed: true,
+			"udp": schema.BoolAttribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
 			},
-			"updated_at": {
-				Type:     schema.TypeInt,
+			"created_at": schema.Int64Attribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+			"updated_at": schema.Int64Attribute{
 				Computed: true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
-func resourceModuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	// Warning or errors can be collected in a slice type
-	c := m.(*Client)
-	var diags diag.Diagnostics
+func (r *moduleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = clientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
 
-	ip := d.Get("ip").(string)
-	port := d.Get("port").(int)
-	driverId := d.Get("driver_id").(string)
-	tls := d.Get("tls").(bool)
-	udp := d.Get("udp").(bool)
-	makebreak := d.Get("makebreak").(bool)
-	uri := d.Get("uri").(string)
-	customName := d.Get("custom_name").(string)
-	notes := d.Get("notes").(string)
-	ignore_connected := d.Get("ignore_connected").(bool)
+func (r *moduleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan moduleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	module, err := c.createModule(ip, driverId, uri, port, tls, udp, makebreak, customName, notes, ignore_connected)
+	createTimeout, diags := plan.Timeouts.Create(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
+	module, err := r.client.Modules.Create(ctx, &api.Module{
+		Ip:              plan.Ip.ValueString(),
+		Uri:             plan.Uri.ValueString(),
+		Port:            int(plan.Port.ValueInt64()),
+		Makebreak:       plan.Makebreak.ValueBool(),
+		CustomName:      plan.CustomName.ValueString(),
+		Notes:           plan.Notes.ValueString(),
+		IgnoreConnected: plan.IgnoreConnected.ValueBool(),
+		IgnoreStartStop: plan.IgnoreStartStop.ValueBool(),
+		DriverId:        plan.DriverId.ValueString(),
+	})
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+		return
 	}
 
-	d.SetId(module.Id)
-	resourceModuleRead(ctx, d, m)
-	return diags
+	plan.fromAPI(module)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
-func resourceModuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	// Warning or errors can be collected in a slice type
-	c := m.(*Client)
-	var diags diag.Diagnostics
-
-	moduleId := d.Id()
+func (r *moduleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state moduleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	module, err := c.getModule(moduleId)
+	module, err := r.client.Modules.Get(ctx, state.Id.ValueString())
+	if api.IsNotFound(err) {
+		tflog.Warn(ctx, "module not found, removing it from state", map[string]interface{}{"id": state.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+		return
 	}
 
-	d.Set("name", module.Name)
-	d.Set("custom_name", module.CustomName)
-	d.Set("driver_id", module.DriverId)
-	d.Set("uri", module.Uri)
-	d.Set("notes", module.Notes)
-	d.Set("ip", module.Ip)
-	d.Set("port", module.Port)
-	d.Set("tls", module.Tls)
-	d.Set("udp", module.Udp)
-	d.Set("makebreak", module.Makebreak)
-	d.Set("ignore_connected", module.IgnoreConnected)
-	d.Set("ignore_startstop", module.IgnoreStartStop)
-	d.Set("id", module.Id)
-	d.Set("created_at", module.CreatedAt)
-	d.Set("updated_at", module.UpdatedAt)
-
-	return diags
+	state.fromAPI(module)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 }
 
-func resourceModuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*Client)
-	module, err := c.getModule(d.Id())
-	if err != nil {
-		return diag.FromErr(err)
+func (r *moduleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan moduleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	module.Id = d.Id()
-
-	if d.HasChange("custom_name") {
-		custom_name := d.Get("custom_name").(string)
-		module.CustomName = custom_name
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
-	if d.HasChange("ip") {
-		ip := d.Get("ip").(string)
-		module.Ip = ip
-	}
-	if d.HasChange("port") {
-		port := d.Get("port").(int)
-		module.Port = port
-	}
-	if d.HasChange("tls") {
-		tls := d.Get("tls").(bool)
-		module.Tls = tls
-	}
-	if d.HasChange("udp") {
-		udp := d.Get("udp").(bool)
-		module.Udp = udp
-	}
-	if d.HasChange("makebreak") {
-		makebreak := d.Get("makebreak").(bool)
-		module.Makebreak = makebreak
-	}
-	if d.HasChange("notes") {
-		notes := d.Get("notes").(string)
-		module.Notes = notes
-	}
-	if d.HasChange("ignore_connected") {
-		ignore_connected := d.Get("ignore_connected").(bool)
-		module.IgnoreConnected = ignore_connected
-	}
-	if d.HasChange("ignore_start_stop") {
-		ignore_start_stop := d.Get("ignore_start_stop").(bool)
-		module.IgnoreStartStop = ignore_start_stop
-	}
-	if d.HasChange("notes") {
-		notes := d.Get("notes").(string)
-		module.Notes = notes
+	module, err := r.client.Modules.Get(ctx, plan.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+		return
 	}
-	if d.HasChange("ignore_connected") {
-		ignore_connected := d.Get("ignore_connected").(bool)
-		module.IgnoreConnected = ignore_connected
+
+	update := &api.ModuleUpdate{
+		Uri:             plan.Uri.ValueString(),
+		Ip:              plan.Ip.ValueString(),
+		Port:            int(plan.Port.ValueInt64()),
+		Tls:             module.Tls,
+		Udp:             module.Udp,
+		Makebreak:       plan.Makebreak.ValueBool(),
+		Notes:           plan.Notes.ValueString(),
+		IgnoreConnected: plan.IgnoreConnected.ValueBool(),
+		IgnoreStartStop: plan.IgnoreStartStop.ValueBool(),
+		DriverId:        module.DriverId,
+		CustomName:      plan.CustomName.ValueString(),
 	}
 
-	module2, err := c.updateModule(module)
+	ctx = logContext(ctx)
+	tflog.Debug(ctx, "updating module", logFields(update))
+
+	module, err = r.client.Modules.Update(ctx, module.Id, update)
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+		return
 	}
 
-	d.SetId(module2.Id)
-
-	return resourceModuleRead(ctx, d, m)
+	plan.fromAPI(module)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
-func resourceModuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	// Warning or errors can be collected in a slice type
-	c := m.(*Client)
-	var diags diag.Diagnostics
+func (r *moduleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state moduleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	id := d.Get("id").(string)
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
 
-	err := c.deleteModule(id)
-	if err != nil {
-		return diag.FromErr(err)
+	err := r.client.Modules.Delete(ctx, state.Id.ValueString())
+	if err != nil && !api.IsNotFound(err) {
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
 	}
+}
+
+func (r *moduleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
 
-	d.SetId("")
-	return diags
+func (m *moduleResourceModel) fromAPI(module *api.Module) {
+	m.Id = types.StringValue(module.Id)
+	m.CustomName = types.StringValue(module.CustomName)
+	m.DriverId = types.StringValue(module.DriverId)
+	m.Uri = types.StringValue(module.Uri)
+	m.Notes = types.StringValue(module.Notes)
+	m.Ip = types.StringValue(module.Ip)
+	m.Port = types.Int64Value(int64(module.Port))
+	m.Makebreak = types.BoolValue(module.Makebreak)
+	m.IgnoreConnected = types.BoolValue(module.IgnoreConnected)
+	m.IgnoreStartStop = types.BoolValue(module.IgnoreStartStop)
+	m.Tls = types.BoolValue(module.Tls)
+	m.Udp = types.BoolValue(module.Udp)
+	m.CreatedAt = types.Int64Value(module.CreatedAt)
+	m.UpdatedAt = types.Int64Value(module.UpdatedAt)
 }
@@ -0,0 +1,133 @@
+package placeos
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/api"
+)
+
+func TestUnitModule_basic(t *testing.T) {
+	engine := testFakeEngine(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testFakeCheckDestroy(engine, "modules", "placeos_module"),
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeProviderConfig(engine) + testModuleConfig("Lights", "10.0.0.1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("placeos_module.test", "id"),
+					resource.TestCheckResourceAttr("placeos_module.test", "custom_name", "Lights"),
+					resource.TestCheckResourceAttr("placeos_module.test", "ip", "10.0.0.1"),
+				),
+			},
+			{
+				Config: testFakeProviderConfig(engine) + testModuleConfig("Level 2 lights", "10.0.0.2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("placeos_module.test", "custom_name", "Level 2 lights"),
+					resource.TestCheckResourceAttr("placeos_module.test", "ip", "10.0.0.2"),
+				),
+			},
+			{
+				ResourceName:      "placeos_module.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testModuleConfig(name string, ip string) string {
+	return testDriverConfig("Lutron") + fmt.Sprintf(`
+resource "placeos_module" "test" {
+  custom_name = %q
+  driver_id   = placeos_driver.test.id
+  ip          = %q
+  port        = 23
+}
+`, name, ip)
+}
+
+func TestAccModule_basic(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-module")
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckModuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccModuleConfig(name, "first", "Bookings"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccStoreID("placeos_module.test", &id),
+					resource.TestCheckResourceAttr("placeos_module.test", "custom_name", "Bookings"),
+					resource.TestCheckResourceAttrPair("placeos_module.test", "driver_id", "placeos_driver.first", "id"),
+				),
+			},
+			{
+				Config: testAccModuleConfig(name, "first", "Level 2 bookings"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDUnchanged("placeos_module.test", &id),
+					resource.TestCheckResourceAttr("placeos_module.test", "custom_name", "Level 2 bookings"),
+				),
+			},
+			{
+				Config: testAccModuleConfig(name, "second", "Level 2 bookings"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDChanged("placeos_module.test", &id),
+					resource.TestCheckResourceAttrPair("placeos_module.test", "driver_id", "placeos_driver.second", "id"),
+				),
+			},
+			{
+				ResourceName:      "placeos_module.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckModuleDestroy(s *terraform.State) error {
+	return testAccCheckDestroy("placeos_module", func(ctx context.Context, c *api.Client, id string) error {
+		_, err := c.Modules.Get(ctx, id)
+		return err
+	})(s)
+}
+
+// testAccModuleConfig declares two drivers so that switching the module
+// between them exercises the driver_id replacement.
+func testAccModuleConfig(name string, driver string, customName string) string {
+	return testAccRepositoryConfig(name, testAccDriversUri) + fmt.Sprintf(`
+resource "placeos_driver" "first" {
+  name          = "%[1]s-first"
+  file_name     = %[2]q
+  default_uri   = "https://placeos.example.com"
+  module_name   = "Bookings"
+  repository_id = placeos_repository.test.id
+  role          = 2
+}
+
+resource "placeos_driver" "second" {
+  name          = "%[1]s-second"
+  file_name     = %[2]q
+  default_uri   = "https://placeos.example.com"
+  module_name   = "Bookings"
+  repository_id = placeos_repository.test.id
+  role          = 2
+}
+
+resource "placeos_module" "test" {
+  custom_name = %[4]q
+  driver_id   = placeos_driver.%[3]s.id
+  uri         = "https://placeos.example.com"
+}
+`, name, testAccDriverFile, driver, customName)
+}
@@ -2,216 +2,248 @@ package placeos
 
 import (
 	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/api"
+)
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+var (
+	_ resource.Resource                = &repositoryResource{}
+	_ resource.ResourceWithConfigure   = &repositoryResource{}
+	_ resource.ResourceWithImportState = &repositoryResource{}
 )
 
-func resourceRepository() *schema.Resource {
-	return &schema.Resource{
-		CreateContext: resourceRepositoryCreate,
-		ReadContext:   resourceRepositoryRead,
-		UpdateContext: resourceRepositoryUpdate,
-		DeleteContext: resourceRepositoryDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+type repositoryResource struct {
+	client *api.Client
+}
 
-		Schema: map[string]*schema.Schema{
-			"name": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+type repositoryResourceModel struct {
+	Id          types.String   `tfsdk:"id"`
+	Name        types.String   `tfsdk:"name"`
+	FolderName  types.String   `tfsdk:"folder_name"`
+	Uri         types.String   `tfsdk:"uri"`
+	RepoType    types.String   `tfsdk:"repo_type"`
+	Description types.String   `tfsdk:"description"`
+	Branch      types.String   `tfsdk:"branch"`
+	Username    types.String   `tfsdk:"username"`
+	Password    types.String   `tfsdk:"password"`
+	CommitHash  types.String   `tfsdk:"commit_hash"`
+	CreatedAt   types.Int64    `tfsdk:"created_at"`
+	UpdatedAt   types.Int64    `tfsdk:"updated_at"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+func newRepositoryResource() resource.Resource {
+	return &repositoryResource{}
+}
+
+func (r *repositoryResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_repository"
+}
+
+func (r *repositoryResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
-			"folder_name": &schema.Schema{
-				Type:     schema.TypeString,
+			"name": schema.StringAttribute{
 				Required: true,
 			},
-			"uri": &schema.Schema{
-				Type:     schema.TypeString,
+			"folder_name": schema.StringAttribute{
 				Required: true,
-				ForceNew: true,
 			},
-			"repo_type": &schema.Schema{
-				Type:     schema.TypeString,
+			"uri": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"repo_type": schema.StringAttribute{
 				Required: true,
 			},
-			"description": &schema.Schema{
-				Type:     schema.TypeString,
+			"description": schema.StringAttribute{
 				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
 			},
-			"branch": &schema.Schema{
-				Type:     schema.TypeString,
+			"branch": schema.StringAttribute{
 				Optional: true,
-				Default:  "master",
+				Computed: true,
+				Default:  stringdefault.StaticString("master"),
 			},
-			"username": &schema.Schema{
-				Type:     schema.TypeString,
+			"username": schema.StringAttribute{
 				Optional: true,
-			},
-			"password": &schema.Schema{
-				Type:      schema.TypeString,
-				Optional:  true,
-				Sensitive: true,
-			},
-			"id": &schema.Schema{
-				Type:     schema.TypeString,
 				Computed: true,
+				Default:  stringdefault.StaticString(""),
 			},
-			"created_at": &schema.Schema{
-				Type:     schema.TypeInt,
-				Computed: true,
+			"password": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Sensitive:   true,
+				Default:     stringdefault.StaticString(""),
+				Description: "The engine does not return the password, it is kept as configured.",
 			},
-			"commit_hash": &schema.Schema{
-				Type:     schema.TypeString,
+			"commit_hash": schema.StringAttribute{
 				Computed: true,
 			},
-			"updated_at": &schema.Schema{
-				Type:     schema.TypeInt,
+			"created_at": schema.Int64Attribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+			"updated_at": schema.Int64Attribute{
 				Computed: true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
-func resourceRepositoryCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	// Warning or errors can be collected in a slice type
-	c := m.(*Client)
-	var diags diag.Diagnostics
-
-	name := d.Get("name").(string)
-	folder_name := d.Get("folder_name").(string)
-	uri := d.Get("uri").(string)
-	repo_type := d.Get("repo_type").(string)
-	description := d.Get("description").(string)
-	branch := d.Get("branch").(string)
-	username := d.Get("username").(string)
-	password := d.Get("password").(string)
-
-	repository, err := c.createRepository(name, folder_name, uri, repo_type, description, branch, username, password)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	d.SetId(repository.Id)
-	resourceRepositoryRead(ctx, d, m)
-	return diags
+func (r *repositoryResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = clientFromProviderData(req.ProviderData, &resp.Diagnostics)
 }
 
-func resourceRepositoryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	// Warning or errors can be collected in a slice type
-	c := m.(*Client)
-	var diags diag.Diagnostics
-
-	repositoryId := d.Id()
-
-	repository, err := c.getRepository(repositoryId)
-	if err != nil {
-		return diag.FromErr(err)
+func (r *repositoryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan repositoryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if err := d.Set("created_at", repository.CreatedAt); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("updated_at", repository.UpdatedAt); err != nil {
-		return diag.FromErr(err)
+	createTimeout, diags := plan.Timeouts.Create(ctx, 10*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if err := d.Set("name", repository.Name); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("description", repository.Description); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("folder_name", repository.FolderName); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("uri", repository.Uri); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("commit_hash", repository.CommitHash); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("branch", repository.Branch); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("username", repository.Username); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("password", repository.Password); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("repo_type", repository.RepoType); err != nil {
-		return diag.FromErr(err)
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	repository, err := r.client.Repositories.Create(ctx, plan.request())
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+		return
 	}
 
-	return diags
+	plan.fromAPI(repository)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
-func resourceRepositoryUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*Client)
-	repository, err := c.getRepository(d.Id())
-
-	if d.HasChange("name") {
-		name := d.Get("name").(string)
-		repository.Name = name
+func (r *repositoryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state repositoryResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if d.HasChange("folder_name") {
-		folder_name := d.Get("folder_name").(string)
-		repository.FolderName = folder_name
+	repository, err := r.client.Repositories.Get(ctx, state.Id.ValueString())
+	if api.IsNotFound(err) {
+		tflog.Warn(ctx, "repository not found, removing it from state", map[string]interface{}{"id": state.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
 	}
-
-	if d.HasChange("uri") {
-		uri := d.Get("uri").(string)
-		repository.Uri = uri
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+		return
 	}
 
-	if d.HasChange("description") {
-		description := d.Get("description").(string)
-		repository.Description = description
-	}
+	state.fromAPI(repository)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
 
-	if d.HasChange("branch") {
-		branch := d.Get("branch").(string)
-		repository.Branch = branch
+func (r *repositoryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan repositoryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if d.HasChange("username") {
-		username := d.Get("username").(string)
-		repository.Username = username
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 10*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
-	if d.HasChange("password") {
-		password := d.Get("password").(string)
-		repository.Password = password
+	repository, err := r.client.Repositories.Update(ctx, plan.Id.ValueString(), plan.request())
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+		return
 	}
 
-	if d.HasChange("repo_type") {
-		repo_type := d.Get("repo_type").(string)
-		repository.RepoType = repo_type
-	}
+	plan.fromAPI(repository)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
 
-	repository2, err := c.updateRepository(repository)
-	if err != nil {
-		return diag.FromErr(err)
+func (r *repositoryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state repositoryResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	d.SetId(repository2.Id)
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
 
-	return resourceRepositoryRead(ctx, d, m)
+	err := r.client.Repositories.Delete(ctx, state.Id.ValueString())
+	if err != nil && !api.IsNotFound(err) {
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+	}
 }
 
-func resourceRepositoryDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	// Warning or errors can be collected in a slice type
-	c := m.(*Client)
-	var diags diag.Diagnostics
-
-	id := d.Get("id").(string)
+func (r *repositoryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
 
-	err := c.deleteRepository(id)
-	if err != nil {
-		return diag.FromErr(err)
+func (m *repositoryResourceModel) request() *api.RepositoryRequest {
+	return &api.RepositoryRequest{
+		Name:        m.Name.ValueString(),
+		FolderName:  m.FolderName.ValueString(),
+		Uri:         m.Uri.ValueString(),
+		RepoType:    m.RepoType.ValueString(),
+		Description: m.Description.ValueString(),
+		Branch:      m.Branch.ValueString(),
+		Username:    m.Username.ValueString(),
+		Password:    m.Password.ValueString(),
 	}
+}
 
-	d.SetId("")
-	return diags
+// fromAPI refreshes the model from repository, except password which the
+// engine never returns.
+func (m *repositoryResourceModel) fromAPI(repository *api.Repository) {
+	if m.Password.IsNull() {
+		m.Password = types.StringValue("")
+	}
+	m.Id = types.StringValue(repository.Id)
+	m.Name = types.StringValue(repository.Name)
+	m.FolderName = types.StringValue(repository.FolderName)
+	m.Uri = types.StringValue(repository.Uri)
+	m.RepoType = types.StringValue(repository.RepoType)
+	m.Description = types.StringValue(repository.Description)
+	m.Branch = types.StringValue(repository.Branch)
+	m.Username = types.StringValue(repository.Username)
+	m.CommitHash = types.StringValue(repository.CommitHash)
+	m.CreatedAt = types.Int64Value(repository.CreatedAt)
+	m.UpdatedAt = types.Int64Value(repository.UpdatedAt)
 }
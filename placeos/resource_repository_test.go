@@ -0,0 +1,147 @@
+package placeos
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/api"
+)
+
+func TestUnitRepository_basic(t *testing.T) {
+	engine := testFakeEngine(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testFakeCheckDestroy(engine, "repositories", "placeos_repository"),
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeProviderConfig(engine) + testRepositoryConfig("Drivers", "main"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("placeos_repository.test", "id"),
+					resource.TestCheckResourceAttr("placeos_repository.test", "name", "Drivers"),
+					resource.TestCheckResourceAttr("placeos_repository.test", "branch", "main"),
+				),
+			},
+			{
+				Config: testFakeProviderConfig(engine) + testRepositoryConfig("Private drivers", "develop"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("placeos_repository.test", "name", "Private drivers"),
+					resource.TestCheckResourceAttr("placeos_repository.test", "branch", "develop"),
+				),
+			},
+			{
+				ResourceName:            "placeos_repository.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+		},
+	})
+}
+
+func TestUnitRepository_readError(t *testing.T) {
+	engine := testFakeEngine(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeProviderConfig(engine) + testRepositoryConfig("Drivers", "main"),
+			},
+			{
+				PreConfig: func() {
+					engine.Fail(http.MethodGet, "/api/engine/v2/repositories/", 1, http.StatusServiceUnavailable, `{"error":"engine restarting"}`)
+				},
+				Config:      testFakeProviderConfig(engine) + testRepositoryConfig("Drivers", "main"),
+				ExpectError: regexp.MustCompile(`503 engine restarting`),
+			},
+		},
+	})
+}
+
+func testRepositoryConfig(name string, branch string) string {
+	return fmt.Sprintf(`
+resource "placeos_repository" "test" {
+  name        = %q
+  folder_name = "drivers"
+  uri         = "https://github.com/placeos/drivers"
+  repo_type   = "driver"
+  branch      = %q
+}
+`, name, branch)
+}
+
+func TestAccRepository_basic(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-repository")
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRepositoryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRepositoryConfig(name, testAccDriversUri),
+				Check: resource.ComposeTestCheckFunc(
+					testAccStoreID("placeos_repository.test", &id),
+					resource.TestCheckResourceAttr("placeos_repository.test", "name", name),
+					resource.TestCheckResourceAttr("placeos_repository.test", "uri", testAccDriversUri),
+				),
+			},
+			{
+				Config: testAccRepositoryConfig(name+"-renamed", testAccDriversUri),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDUnchanged("placeos_repository.test", &id),
+					resource.TestCheckResourceAttr("placeos_repository.test", "name", name+"-renamed"),
+				),
+			},
+			{
+				Config: testAccRepositoryConfig(name+"-renamed", testAccPrivateDriversUri),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDChanged("placeos_repository.test", &id),
+					resource.TestCheckResourceAttr("placeos_repository.test", "uri", testAccPrivateDriversUri),
+				),
+			},
+			{
+				ResourceName:            "placeos_repository.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+		},
+	})
+}
+
+// Public driver repositories the engine can clone during acceptance tests.
+const (
+	testAccDriversUri        = "https://github.com/PlaceOS/drivers"
+	testAccPrivateDriversUri = "https://github.com/PlaceOS/private-drivers"
+)
+
+func testAccCheckRepositoryDestroy(s *terraform.State) error {
+	return testAccCheckDestroy("placeos_repository", func(ctx context.Context, c *api.Client, id string) error {
+		_, err := c.Repositories.Get(ctx, id)
+		return err
+	})(s)
+}
+
+func testAccRepositoryConfig(name string, uri string) string {
+	return fmt.Sprintf(`
+resource "placeos_repository" "test" {
+  name        = %q
+  folder_name = %q
+  uri         = %q
+  repo_type   = "driver"
+  branch      = "master"
+}
+`, name, name, uri)
+}
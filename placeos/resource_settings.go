@@ -2,181 +2,229 @@ package placeos
 
 import (
 	"context"
-	"encoding/json"
-	"os"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/api"
+)
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+var (
+	_ resource.Resource                = &settingResource{}
+	_ resource.ResourceWithConfigure   = &settingResource{}
+	_ resource.ResourceWithImportState = &settingResource{}
 )
 
-func resourceSetting() *schema.Resource {
-	return &schema.Resource{
-		CreateContext: resourceSettingCreate,
-		ReadContext:   resourceSettingRead,
-		UpdateContext: resourceSettingUpdate,
-		DeleteContext: resourceSettingDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+type settingResource struct {
+	client *api.Client
+}
 
-		Schema: map[string]*schema.Schema{
-			"parent_type": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+type settingResourceModel struct {
+	Id              types.String   `tfsdk:"id"`
+	ParentType      types.String   `tfsdk:"parent_type"`
+	ParentId        types.String   `tfsdk:"parent_id"`
+	Keys            types.List     `tfsdk:"keys"`
+	SettingsString  types.String   `tfsdk:"settings_string"`
+	EncryptionLevel types.Int64    `tfsdk:"encryption_level"`
+	CreatedAt       types.Int64    `tfsdk:"created_at"`
+	UpdatedAt       types.Int64    `tfsdk:"updated_at"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+}
+
+func newSettingResource() resource.Resource {
+	return &settingResource{}
+}
+
+func (r *settingResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_setting"
+}
+
+func (r *settingResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
-			"parent_id": &schema.Schema{
-				Type:     schema.TypeString,
+			"parent_type": schema.StringAttribute{
 				Required: true,
-				ForceNew: true,
 			},
-			"keys": &schema.Schema{
-				Type: schema.TypeList,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
-				Required: true,
+			"parent_id": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
 			},
-			"settings_string": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+			"keys": schema.ListAttribute{
+				ElementType: types.StringType,
+				Required:    true,
 			},
-			"encryption_level": &schema.Schema{
-				Type:     schema.TypeInt,
+			"settings_string": schema.StringAttribute{
 				Required: true,
 			},
-			"id": &schema.Schema{
-				Type:     schema.TypeString,
-				Computed: true,
+			"encryption_level": schema.Int64Attribute{
+				Required: true,
 			},
-			"created_at": &schema.Schema{
-				Type:     schema.TypeInt,
-				Computed: true,
+			"created_at": schema.Int64Attribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
 			},
-			"updated_at": &schema.Schema{
-				Type:     schema.TypeInt,
+			"updated_at": schema.Int64Attribute{
 				Computed: true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
-func resourceSettingCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	// Warning or errors can be collected in a slice type
-	c := m.(*Client)
-
-	setting_string := d.Get("settings_string").(string)
-	encryption_level := d.Get("encryption_level").(int)
-	parent_type := d.Get("parent_type").(string)
-	parent_id := d.Get("parent_id").(string)
-	keys_interface := d.Get("keys")
+func (r *settingResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = clientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
 
-	keys := make([]string, len(keys_interface.([]interface{})))
-	for i, v := range keys_interface.([]interface{}) {
-		keys[i] = v.(string)
+func (r *settingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan settingResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	setting, err := c.CreateSetting("", parent_id, parent_type, setting_string, encryption_level, keys)
-	if err != nil {
-		return diag.FromErr(err)
+	createTimeout, diags := plan.Timeouts.Create(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
-	d.SetId(setting.Id)
-	return resourceSettingRead(ctx, d, m)
-}
+	setting := &api.Setting{}
+	plan.toAPI(ctx, setting, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-// add a resource driver read function with diagnostic tool for each field
-func resourceSettingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	// Warning or errors can be collected in a slice type
-	c := m.(*Client)
-	var diags diag.Diagnostics
-	id := d.Get("id").(string)
-	setting, err := c.getSetting(id)
+	setting, err := r.client.Settings.Create(ctx, setting)
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+		return
 	}
 
-	d.Set("name", setting.Name)
-	d.Set("parent_type", setting.ParentType)
-	d.Set("parent_id", setting.ParentId)
-	d.Set("keys", setting.Keys)
-	d.Set("settings_string", setting.SettingsString)
-	d.Set("encryption_level", setting.EncryptionLevel)
-
-	return diags
+	plan.fromAPI(ctx, setting, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
-// add a resource driver update function with diagnostic tool for each field
-func resourceSettingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	// Warning or errors can be collected in a slice type
-	c := m.(*Client)
-	id := d.Get("id").(string)
-	setting, err := c.getSetting(id)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	file, err := os.Create("/tmp/setting.json")
-	if err != nil {
-		return diag.FromErr(err)
+func (r *settingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state settingResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	text, err := json.Marshal(setting)
-
+	setting, err := r.client.Settings.Get(ctx, state.Id.ValueString())
+	if api.IsNotFound(err) {
+		tflog.Warn(ctx, "setting not found, removing it from state", map[string]interface{}{"id": state.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+		return
 	}
 
-	file.Write(text)
-	file.Close()
+	state.fromAPI(ctx, setting, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
 
-	if err != nil {
-		return diag.FromErr(err)
+func (r *settingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan settingResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	// check each field has change and replace it if it has
 
-	setting.Id = id
-	if d.HasChange("name") {
-		setting.Name = d.Get("name").(string)
-	}
-	if d.HasChange("parent_type") {
-		setting.ParentType = d.Get("parent_type").(string)
-	}
-	if d.HasChange("parent_id") {
-		setting.ParentId = d.Get("parent_id").(string)
-	}
-	if d.HasChange("keys") {
-		setting.Keys = d.Get("keys").([]string)
-	}
-	if d.HasChange("settings_string") {
-		setting.SettingsString = d.Get("settings_string").(string)
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if d.HasChange("encryption_level") {
-		setting.EncryptionLevel = d.Get("encryption_level").(int)
-	}
-	setting, err = c.updateSetting(setting)
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
+	setting, err := r.client.Settings.Get(ctx, plan.Id.ValueString())
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+		return
+	}
+	ctx = logContext(ctx)
+	tflog.Debug(ctx, "read setting before update", logFields(setting))
+
+	plan.toAPI(ctx, setting, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	setting, err = c.updateSetting(setting)
+	setting, err = r.client.Settings.Update(ctx, setting)
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+		return
 	}
 
-	return resourceSettingRead(ctx, d, m)
+	plan.fromAPI(ctx, setting, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
-// add a resource driver delete function with diagnostic as return
-func resourceSettingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*Client)
-	var diags diag.Diagnostics
+func (r *settingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state settingResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	id := d.Get("id").(string)
-	err := c.deleteSetting(id)
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
 
-	if err != nil {
-		return diag.FromErr(err)
+	err := r.client.Settings.Delete(ctx, state.Id.ValueString())
+	if err != nil && !api.IsNotFound(err) {
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
 	}
+}
+
+func (r *settingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func (m *settingResourceModel) toAPI(ctx context.Context, setting *api.Setting, diags *diag.Diagnostics) {
+	setting.ParentType = m.ParentType.ValueString()
+	setting.ParentId = m.ParentId.ValueString()
+	setting.Keys = listStrings(ctx, m.Keys, diags)
+	setting.SettingsString = m.SettingsString.ValueString()
+	setting.EncryptionLevel = int(m.EncryptionLevel.ValueInt64())
+}
 
-	return diags
+func (m *settingResourceModel) fromAPI(ctx context.Context, setting *api.Setting, diags *diag.Diagnostics) {
+	m.Id = types.StringValue(setting.Id)
+	m.ParentType = types.StringValue(setting.ParentType)
+	m.ParentId = types.StringValue(setting.ParentId)
+	m.Keys = stringList(ctx, setting.Keys, diags)
+	m.SettingsString = types.StringValue(setting.SettingsString)
+	m.EncryptionLevel = types.Int64Value(int64(setting.EncryptionLevel))
+	m.CreatedAt = types.Int64Value(setting.CreatedAt)
+	m.UpdatedAt = types.Int64Value(setting.UpdatedAt)
 }
@@ -0,0 +1,124 @@
+package placeos
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/api"
+)
+
+func TestUnitSetting_basic(t *testing.T) {
+	engine := testFakeEngine(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testFakeCheckDestroy(engine, "settings", "placeos_setting"),
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeProviderConfig(engine) + testSettingConfig(`{\"floor\":1}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("placeos_setting.test", "id"),
+					resource.TestCheckResourceAttrPair("placeos_setting.test", "parent_id", "placeos_zone.test", "id"),
+					resource.TestCheckResourceAttr("placeos_setting.test", "settings_string", `{"floor":1}`),
+				),
+			},
+			{
+				ResourceName:            "placeos_setting.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"settings_string"},
+			},
+		},
+	})
+}
+
+func testSettingConfig(settings string) string {
+	return testZoneConfig("Building 1", 10) + fmt.Sprintf(`
+resource "placeos_setting" "test" {
+  parent_type      = "zone"
+  parent_id        = placeos_zone.test.id
+  keys             = ["floor"]
+  settings_string  = "%s"
+  encryption_level = 0
+}
+`, settings)
+}
+
+func TestAccSetting_basic(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-setting")
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckSettingDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSettingConfig(name, "first", "floor", 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccStoreID("placeos_setting.test", &id),
+					resource.TestCheckResourceAttrPair("placeos_setting.test", "parent_id", "placeos_zone.first", "id"),
+					resource.TestCheckResourceAttr("placeos_setting.test", "keys.0", "floor"),
+				),
+			},
+			{
+				Config: testAccSettingConfig(name, "first", "level", 2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDUnchanged("placeos_setting.test", &id),
+					resource.TestCheckResourceAttr("placeos_setting.test", "keys.0", "level"),
+					resource.TestCheckResourceAttr("placeos_setting.test", "settings_string", `{"level":2}`),
+				),
+			},
+			{
+				Config: testAccSettingConfig(name, "second", "level", 2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDChanged("placeos_setting.test", &id),
+					resource.TestCheckResourceAttrPair("placeos_setting.test", "parent_id", "placeos_zone.second", "id"),
+				),
+			},
+			{
+				ResourceName:            "placeos_setting.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"settings_string"},
+			},
+		},
+	})
+}
+
+func testAccCheckSettingDestroy(s *terraform.State) error {
+	return testAccCheckDestroy("placeos_setting", func(ctx context.Context, c *api.Client, id string) error {
+		_, err := c.Settings.Get(ctx, id)
+		return err
+	})(s)
+}
+
+// testAccSettingConfig declares two zones so that moving the setting between
+// them exercises the parent_id replacement.
+func testAccSettingConfig(name string, zone string, key string, value int) string {
+	return fmt.Sprintf(`
+resource "placeos_zone" "first" {
+  name = "%[1]s-first"
+  tags = ["building"]
+}
+
+resource "placeos_zone" "second" {
+  name = "%[1]s-second"
+  tags = ["building"]
+}
+
+resource "placeos_setting" "test" {
+  parent_type      = "zone"
+  parent_id        = placeos_zone.%[2]s.id
+  keys             = [%[3]q]
+  settings_string  = jsonencode({ %[3]s = %[4]d })
+  encryption_level = 0
+}
+`, name, zone, key, value)
+}
@@ -2,318 +2,642 @@ package placeos
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/api"
+)
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+var (
+	_ resource.Resource                 = &systemResource{}
+	_ resource.ResourceWithConfigure    = &systemResource{}
+	_ resource.ResourceWithImportState  = &systemResource{}
+	_ resource.ResourceWithUpgradeState = &systemResource{}
 )
 
-func resourceSystem() *schema.Resource {
-	return &schema.Resource{
-		CreateContext: resourceSystemCreate,
-		ReadContext:   resourceSystemRead,
-		UpdateContext: resourceSystemUpdate,
-		DeleteContext: resourceSystemDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+type systemResource struct {
+	client *api.Client
+}
+
+type systemResourceModel struct {
+	Id                 types.String   `tfsdk:"id"`
+	Name               types.String   `tfsdk:"name"`
+	Description        types.String   `tfsdk:"description"`
+	Email              types.String   `tfsdk:"email"`
+	DisplayName        types.String   `tfsdk:"display_name"`
+	Code               types.String   `tfsdk:"code"`
+	Timezone           types.String   `tfsdk:"timezone"`
+	SupportUrl         types.String   `tfsdk:"support_url"`
+	MapId              types.String   `tfsdk:"map_id"`
+	Bookable           types.Bool     `tfsdk:"bookable"`
+	Version            types.Int64    `tfsdk:"version"`
+	InstalledUiDevices types.Int64    `tfsdk:"installed_ui_devices"`
+	Capacity           types.Int64    `tfsdk:"capacity"`
+	Images             types.List     `tfsdk:"images"`
+	Zones              types.List     `tfsdk:"zones"`
+	Modules            []systemModule `tfsdk:"module"`
+	Features           types.List     `tfsdk:"features"`
+	Running            types.Bool     `tfsdk:"running"`
+	CreatedAt          types.Int64    `tfsdk:"created_at"`
+	UpdatedAt          types.Int64    `tfsdk:"updated_at"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+type systemModule struct {
+	Id types.String `tfsdk:"id"`
+}
+
+func newSystemResource() resource.Resource {
+	return &systemResource{}
+}
+
+func (r *systemResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_system"
+}
 
-		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:     schema.TypeString,
+func (r *systemResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: 1,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"name": schema.StringAttribute{
 				Required: true,
 			},
-			"description": {
-				Type:     schema.TypeString,
+			"description": schema.StringAttribute{
 				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
 			},
-			"email": {
-				Type:     schema.TypeString,
+			"email": schema.StringAttribute{
 				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
 			},
-			"display_name": {
-				Type:     schema.TypeString,
+			"display_name": schema.StringAttribute{
 				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
 			},
-			"code": {
-				Type:     schema.TypeString,
+			"code": schema.StringAttribute{
 				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
 			},
-
-			"timezone": {
-				Type:     schema.TypeString,
+			"timezone": schema.StringAttribute{
 				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
 			},
-
-			"support_url": {
-				Type:     schema.TypeString,
+			"support_url": schema.StringAttribute{
 				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
 			},
-			"map_id": {
-				Type:     schema.TypeString,
+			"map_id": schema.StringAttribute{
 				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
 			},
-			"bookable": {
-				Type:     schema.TypeBool,
+			"bookable": schema.BoolAttribute{
 				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
 			},
-			"version": {
-				Type:     schema.TypeInt,
-				Optional: true,
-				Default:  0,
+			"version": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Version of the system in the engine, sent back on every update so that concurrent edits are detected.",
 			},
-			"installed_ui_devices": {
-				Type:     schema.TypeInt,
+			"installed_ui_devices": schema.Int64Attribute{
 				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(0),
 			},
-			"capacity": {
-				Type:     schema.TypeInt,
+			"capacity": schema.Int64Attribute{
 				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(0),
 			},
-			"images": &schema.Schema{
-				Type: schema.TypeList,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
-				Optional: true,
+			"images": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Default:     listdefault.StaticValue(emptyStringList),
 			},
-
-			"zones": &schema.Schema{
-				Type: schema.TypeList,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
-				Required: true,
+			"zones": schema.ListAttribute{
+				ElementType: types.StringType,
+				Required:    true,
 			},
-			"modules": &schema.Schema{
-				Type: schema.TypeList,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
-				Optional: true,
+			"features": schema.ListAttribute{
+				ElementType:   types.StringType,
+				Computed:      true,
+				PlanModifiers: []planmodifier.List{listplanmodifier.UseStateForUnknown()},
 			},
-			"features": &schema.Schema{
-				Type: schema.TypeList,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
-				Computed: true,
+			"running": schema.BoolAttribute{
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+				Description: "Whether the modules of the system are started. When set, apply starts or stops them to match, " +
+					"modules ignoring start and stop are left alone. When unset, the current state is only reported.",
 			},
-			"id": {
-				Type:     schema.TypeString,
-				Computed: true,
+			"created_at": schema.Int64Attribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
 			},
-			"created_at": {
-				Type:     schema.TypeInt,
+			"updated_at": schema.Int64Attribute{
 				Computed: true,
 			},
-			"updated_at": {
-				Type:     schema.TypeInt,
-				Computed: true,
+		},
+		Blocks: map[string]schema.Block{
+			"module": schema.ListNestedBlock{
+				Description: "Modules of the system, in order. The order decides the module index, e.g. `Display_1`, `Display_2`. " +
+					"A module removed from the system is kept, even when no other system uses it.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Required: true,
+						},
+					},
+				},
 			},
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
 
-func resourceSystemCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	// Warning or errors can be collected in a slice type
-	c := m.(*Client)
-	var diags diag.Diagnostics
+func (r *systemResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = clientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
 
-	//  "description" 	"email" 	"display_name" 	"code" 	"timezone" 	"support_url" 	"map_id" 	"bookable" 	"version" 	"installed_ui_devices" "capacity"
-	name := d.Get("name").(string)
-	description := d.Get("description").(string)
-	email := d.Get("email").(string)
-	displayName := d.Get("display_name").(string)
-	code := d.Get("code").(string)
-	timezone := d.Get("timezone").(string)
-	supportUrl := d.Get("support_url").(string)
-	mapId := d.Get("map_id").(string)
-	bookable := d.Get("bookable").(bool)
-	version := d.Get("version").(int)
-	installedUiDevices := d.Get("installed_ui_devices").(int)
-	capacity := d.Get("capacity").(int)
+func (r *systemResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan systemResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	// "images" "zones" "modules" "features"
-	images_interface := d.Get("images")
+	createTimeout, diags := plan.Timeouts.Create(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
-	images := make([]string, len(images_interface.([]interface{})))
-	for i, v := range images_interface.([]interface{}) {
-		images[i] = v.(string)
+	system := &api.System{}
+	plan.toAPI(ctx, system, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	system.Modules = plan.moduleIds()
 
-	zones_interface := d.Get("zones")
-	zoneIds := make([]string, len(zones_interface.([]interface{})))
-	for i, v := range zones_interface.([]interface{}) {
-		zoneIds[i] = v.(string)
+	system, err := r.client.Systems.Create(ctx, system)
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+		return
 	}
 
-	modules_interface := d.Get("modules")
-	moduleIds := make([]string, len(modules_interface.([]interface{})))
-	for i, v := range modules_interface.([]interface{}) {
-		moduleIds[i] = v.(string)
+	if err := r.setRunning(ctx, system.Id, plan.Running); err != nil {
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+		return
 	}
 
-	features_interface := d.Get("features")
-	features := make([]string, len(features_interface.([]interface{})))
-	for i, v := range features_interface.([]interface{}) {
-		features[i] = v.(string)
+	plan.fromAPI(ctx, system, &resp.Diagnostics)
+	if plan.Running.IsUnknown() {
+		plan.Running = r.observeRunning(ctx, system, types.BoolNull(), &resp.Diagnostics)
 	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
 
-	system, err := c.CreateSystem(name, zoneIds, email, displayName, supportUrl, int64(installedUiDevices), int64(capacity), bookable, description, features, mapId, moduleIds, timezone, code, int64(version), images)
+func (r *systemResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state systemResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
+	system, err := r.client.Systems.Get(ctx, state.Id.ValueString())
+	if api.IsNotFound(err) {
+		tflog.Warn(ctx, "system not found, removing it from state", map[string]interface{}{"id": state.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+		return
 	}
 
-	d.SetId(system.Id)
-	resourceSystemRead(ctx, d, m)
-	return diags
+	state.fromAPI(ctx, system, &resp.Diagnostics)
+	state.Running = r.observeRunning(ctx, system, state.Running, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 }
 
-func resourceSystemRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	// Warning or errors can be collected in a slice type
-	c := m.(*Client)
-	var diags diag.Diagnostics
+func (r *systemResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state systemResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
-	systemId := d.Id()
+	system := r.applyChanges(ctx, &plan, &state, resp.State.Schema, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	system, err := c.GetSystem(systemId)
+	system, err := r.syncModules(ctx, system, plan.moduleIds())
 	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	d.Set("name", system.Name)
-	d.Set("description", system.Description)
-	d.Set("email", system.Email)
-	d.Set("display_name", system.DisplayName)
-	d.Set("code", system.Code)
-	d.Set("timezone", system.Timezone)
-	d.Set("support_url", system.SupportUrl)
-	d.Set("map_id", system.MapId)
-	d.Set("bookable", system.Bookable)
-	d.Set("version", system.Version)
-	d.Set("installed_ui_devices", system.InstalledUiDevices)
-	d.Set("capacity", system.Capacity)
-	d.Set("images", system.Images)
-	d.Set("zones", system.Zones)
-	d.Set("modules", system.Modules)
-	d.Set("features", system.Features)
-	d.Set("id", system.Id)
-	d.Set("created_at", system.CreatedAt)
-	d.Set("updated_at", system.UpdatedAt)
-
-	return diags
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+		return
+	}
+
+	// modules just added are started or stopped along with the others
+	var running types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("running"), &running)...)
+	if !running.IsNull() && (!running.Equal(state.Running) || !slices.Equal(plan.moduleIds(), state.moduleIds())) {
+		if err := r.setRunning(ctx, system.Id, running); err != nil {
+			resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+			return
+		}
+	}
+	if plan.Running.IsUnknown() {
+		plan.Running = r.observeRunning(ctx, system, state.Running, &resp.Diagnostics)
+	}
+
+	// attributes changed remotely are kept by the engine but not reported
+	// here, the next refresh shows them as drift
+	plan.computedFromAPI(ctx, system, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
-func resourceSystemUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*Client)
-	system, err := c.GetSystem(d.Id())
+func (r *systemResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state systemResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	if err != nil {
-		return diag.FromErr(err)
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.client.Systems.Delete(ctx, state.Id.ValueString())
+	if err != nil && !api.IsNotFound(err) {
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+	}
+}
+
+func (r *systemResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func (r *systemResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// version 0 kept the modules as a flat list of ids
+		0: {StateUpgrader: upgradeSystemStateV0},
 	}
+}
 
-	if d.HasChange("name") {
-		name := d.Get("name").(string)
-		system.Name = name
+func upgradeSystemStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(req.RawState.JSON, &raw); err != nil {
+		resp.Diagnostics.AddError("Unable to upgrade placeos_system state", err.Error())
+		return
 	}
-	if d.HasChange("description") {
-		description := d.Get("description").(string)
-		system.Description = description
+
+	modules := []interface{}{}
+	ids, _ := raw["modules"].([]interface{})
+	for _, id := range ids {
+		modules = append(modules, map[string]interface{}{"id": id})
 	}
-	if d.HasChange("email") {
-		email := d.Get("email").(string)
-		system.Email = email
+	delete(raw, "modules")
+	raw["module"] = modules
+
+	upgraded, err := json.Marshal(raw)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to upgrade placeos_system state", err.Error())
+		return
 	}
-	if d.HasChange("display_name") {
-		displayName := d.Get("display_name").(string)
-		system.DisplayName = displayName
+	resp.DynamicValue = &tfprotov6.DynamicValue{JSON: upgraded}
+}
+
+// syncModules brings the modules of system to wanted. The modules kept are
+// put in their wanted order, leaving out the removed ones, in a single
+// patch, then the new ones are added one by one with the dedicated endpoint
+// and a remaining difference in order is patched last. Modules are never
+// removed with the dedicated endpoint: the engine would delete the ones no
+// other system uses, including modules managed by placeos_module.
+func (r *systemResource) syncModules(ctx context.Context, system *api.System, wanted []string) (*api.System, error) {
+	present := map[string]bool{}
+	for _, id := range system.Modules {
+		present[id] = true
 	}
-	if d.HasChange("code") {
-		code := d.Get("code").(string)
-		system.Code = code
+	kept := []string{}
+	for _, id := range wanted {
+		if present[id] {
+			kept = append(kept, id)
+		}
 	}
-	if d.HasChange("timezone") {
-		timezone := d.Get("timezone").(string)
-		system.Timezone = timezone
+
+	var err error
+	if !slices.Equal(system.Modules, kept) {
+		tflog.Debug(ctx, "removing and reordering system modules", map[string]interface{}{"id": system.Id, "modules": kept})
+		system, err = r.client.Systems.SetModules(ctx, system.Id, system.Version, kept)
+		if err != nil {
+			return nil, err
+		}
 	}
-	if d.HasChange("support_url") {
-		supportUrl := d.Get("support_url").(string)
-		system.SupportUrl = supportUrl
+
+	for _, id := range wanted {
+		if present[id] {
+			continue
+		}
+		tflog.Debug(ctx, "adding module to system", map[string]interface{}{"id": system.Id, "module_id": id})
+		system, err = r.client.Systems.AddModule(ctx, system.Id, id)
+		if err != nil {
+			return nil, err
+		}
 	}
-	if d.HasChange("map_id") {
-		mapId := d.Get("map_id").(string)
-		system.MapId = mapId
+
+	if !slices.Equal(system.Modules, wanted) {
+		tflog.Debug(ctx, "reordering system modules", map[string]interface{}{"id": system.Id, "modules": wanted})
+		system, err = r.client.Systems.SetModules(ctx, system.Id, system.Version, wanted)
+		if err != nil {
+			return nil, err
+		}
 	}
-	if d.HasChange("bookable") {
-		bookable := d.Get("bookable").(bool)
-		system.Bookable = bookable
+
+	return system, nil
+}
+
+// setRunning starts or stops the modules of the system, an unset running
+// leaves them as they are.
+func (r *systemResource) setRunning(ctx context.Context, id string, running types.Bool) error {
+	if running.IsNull() || running.IsUnknown() {
+		return nil
 	}
-	if d.HasChange("version") {
-		version := d.Get("version").(int)
-		system.Version = int64(version)
+
+	if running.ValueBool() {
+		tflog.Debug(ctx, "starting system", map[string]interface{}{"id": id})
+		return r.client.Systems.Start(ctx, id)
 	}
-	if d.HasChange("installed_ui_devices") {
-		installedUiDevices := d.Get("installed_ui_devices").(int)
-		system.InstalledUiDevices = int64(installedUiDevices)
+	tflog.Debug(ctx, "stopping system", map[string]interface{}{"id": id})
+	return r.client.Systems.Stop(ctx, id)
+}
+
+// observeRunning reports whether the modules of system are running, modules
+// ignoring start and stop aside. A system whose modules are not all in the
+// expected state is reported as the opposite so that the next apply
+// converges it. Without any module to look at, expected is kept. The
+// modules are read with a single list request.
+func (r *systemResource) observeRunning(ctx context.Context, system *api.System, expected types.Bool, diags *diag.Diagnostics) types.Bool {
+	modules, err := r.client.Modules.List(ctx, api.ModuleListOptions{ControlSystemId: system.Id})
+	if err != nil {
+		diags.Append(diagnosticsFromErr(ctx, err, nil)...)
+		return expected
 	}
-	if d.HasChange("capacity") {
-		capacity := d.Get("capacity").(int)
-		system.Capacity = int64(capacity)
+
+	started, stopped := 0, 0
+	for _, module := range modules {
+		if module.IgnoreStartStop {
+			continue
+		}
+		if module.Running {
+			started++
+		} else {
+			stopped++
+		}
 	}
-	if d.HasChange("zones") {
-		zones_interface := d.Get("zones")
-		zoneIds := make([]string, len(zones_interface.([]interface{})))
-		for i, v := range zones_interface.([]interface{}) {
-			zoneIds[i] = v.(string)
+
+	switch {
+	case started == 0 && stopped == 0:
+		if expected.IsNull() || expected.IsUnknown() {
+			return types.BoolValue(false)
 		}
-		system.Zones = zoneIds
+		return expected
+	case expected.IsNull() || expected.IsUnknown() || expected.ValueBool():
+		return types.BoolValue(stopped == 0)
+	default:
+		return types.BoolValue(started > 0)
+	}
+}
+
+// applyChanges replaces the system with the attributes changed by the plan
+// and returns it. The changes are applied on top of the system as read from
+// the engine, along with its version, so attributes edited elsewhere since
+// the last refresh are kept. The engine rejects the update when the system
+// changed in between, it is then read again, up to maxConflictRetries
+// times. Planned and remote changes to the same attribute are a conflict.
+func (r *systemResource) applyChanges(ctx context.Context, plan *systemResourceModel, state *systemResourceModel, schema attributeSchema, diags *diag.Diagnostics) *api.System {
+	planned, previous := &api.System{}, &api.System{}
+	plan.toAPI(ctx, planned, diags)
+	state.toAPI(ctx, previous, diags)
+	if diags.HasError() {
+		return nil
 	}
-	if d.HasChange("modules") {
-		modules_interface := d.Get("modules")
-		moduleIds := make([]string, len(modules_interface.([]interface{})))
-		for i, v := range modules_interface.([]interface{}) {
-			moduleIds[i] = v.(string)
+	changes := changedSystemFields(previous, planned)
+
+	for attempt := 0; ; attempt++ {
+		system, err := r.client.Systems.Get(ctx, state.Id.ValueString())
+		if err != nil {
+			diags.Append(diagnosticsFromErr(ctx, err, nil)...)
+			return nil
+		}
+		// the module list is synced on its own by syncModules, the system
+		// itself is only replaced when one of its attributes changed
+		if len(changes) == 0 {
+			return system
+		}
+
+		// a remote change is only a conflict when it differs from the plan
+		remote, diverged := changedSystemFields(previous, system), changedSystemFields(planned, system)
+		var conflicts []string
+		for _, field := range changes {
+			if slices.Contains(remote, field) && slices.Contains(diverged, field) {
+				conflicts = append(conflicts, field)
+			}
 		}
-		system.Modules = moduleIds
+		if len(conflicts) > 0 {
+			diags.AddError(
+				"System changed in PlaceOS",
+				fmt.Sprintf("%s of system %s changed in PlaceOS since it was last read at version %d, now %d, and the plan changes it too. "+
+					"Run terraform plan again to review the remote changes.",
+					strings.Join(conflicts, ", "), system.Id, state.Version.ValueInt64(), system.Version),
+			)
+			return nil
+		}
+
+		tflog.Debug(ctx, "updating system", map[string]interface{}{"id": system.Id, "version": system.Version, "changes": changes})
+		mergeSystemFields(system, planned, changes, diags)
+		if diags.HasError() {
+			return nil
+		}
+
+		updated, err := r.client.Systems.Update(ctx, system)
+		if api.IsConflict(err) && attempt < maxConflictRetries {
+			tflog.Warn(ctx, "system changed during the update, reading it again", map[string]interface{}{"id": system.Id})
+			continue
+		}
+		if err != nil {
+			diags.Append(diagnosticsFromErr(ctx, err, schema)...)
+			return nil
+		}
+
+		return updated
+	}
+}
+
+// maxConflictRetries bounds how many times an update rejected because of a
+// concurrent edit is tried again.
+const maxConflictRetries = 3
+
+// systemFields returns the attributes of system managed through the
+// resource, keyed by their engine field name. Modules are managed with
+// their own endpoints and the rest is computed by the engine.
+func systemFields(system *api.System) map[string]interface{} {
+	raw, _ := json.Marshal(system)
+	fields := map[string]interface{}{}
+	json.Unmarshal(raw, &fields)
+
+	for _, computed := range []string{"id", "created_at", "updated_at", "version", "features", "type", "modules"} {
+		delete(fields, computed)
 	}
-	if d.HasChange("features") {
-		features_interface := d.Get("features")
-		features := make([]string, len(features_interface.([]interface{})))
-		for i, v := range features_interface.([]interface{}) {
-			features[i] = v.(string)
+	for field, value := range fields {
+		if value == nil {
+			fields[field] = []interface{}{}
 		}
-		system.Features = features
 	}
 
-	if d.HasChange("images") {
-		images_interface := d.Get("images")
-		images := make([]string, len(images_interface.([]interface{})))
-		for i, v := range images_interface.([]interface{}) {
-			images[i] = v.(string)
+	return fields
+}
+
+// changedSystemFields lists the fields that differ between two systems, in
+// order.
+func changedSystemFields(from *api.System, to *api.System) []string {
+	before, after := systemFields(from), systemFields(to)
+
+	var changed []string
+	for field := range after {
+		if !reflect.DeepEqual(before[field], after[field]) {
+			changed = append(changed, field)
 		}
-		system.Images = images
 	}
+	sort.Strings(changed)
 
-	d.SetId(system.Id)
+	return changed
+}
 
-	c.UpdateSystem(system)
+// mergeSystemFields copies the given fields of planned onto system.
+func mergeSystemFields(system *api.System, planned *api.System, fields []string, diags *diag.Diagnostics) {
+	values := systemFields(planned)
+	merged := map[string]interface{}{}
+	for _, field := range fields {
+		merged[field] = values[field]
+	}
 
-	return resourceSystemRead(ctx, d, m)
+	raw, err := json.Marshal(merged)
+	if err == nil {
+		err = json.Unmarshal(raw, system)
+	}
+	if err != nil {
+		diags.AddError("Unable to merge the planned system changes", err.Error())
+	}
 }
 
-func resourceSystemDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	// Warning or errors can be collected in a slice type
-	c := m.(*Client)
-	var diags diag.Diagnostics
+func (m *systemResourceModel) moduleIds() []string {
+	ids := []string{}
+	for _, module := range m.Modules {
+		ids = append(ids, module.Id.ValueString())
+	}
 
-	id := d.Get("id").(string)
+	return ids
+}
 
-	err := c.DeleteSystem(id)
-	if err != nil {
-		return diag.FromErr(err)
+// toAPI copies the planned attributes onto system. The version and the
+// modules are managed separately.
+func (m *systemResourceModel) toAPI(ctx context.Context, system *api.System, diags *diag.Diagnostics) {
+	system.Name = m.Name.ValueString()
+	system.Description = m.Description.ValueString()
+	system.Email = m.Email.ValueString()
+	system.DisplayName = m.DisplayName.ValueString()
+	system.Code = m.Code.ValueString()
+	system.Timezone = m.Timezone.ValueString()
+	system.SupportUrl = m.SupportUrl.ValueString()
+	system.MapId = m.MapId.ValueString()
+	system.Bookable = m.Bookable.ValueBool()
+	system.InstalledUiDevices = m.InstalledUiDevices.ValueInt64()
+	system.Capacity = m.Capacity.ValueInt64()
+	system.Images = listStrings(ctx, m.Images, diags)
+	system.Zones = listStrings(ctx, m.Zones, diags)
+}
+
+func (m *systemResourceModel) fromAPI(ctx context.Context, system *api.System, diags *diag.Diagnostics) {
+	m.Name = types.StringValue(system.Name)
+	m.Description = types.StringValue(system.Description)
+	m.Email = types.StringValue(system.Email)
+	m.DisplayName = types.StringValue(system.DisplayName)
+	m.Code = types.StringValue(system.Code)
+	m.Timezone = types.StringValue(system.Timezone)
+	m.SupportUrl = types.StringValue(system.SupportUrl)
+	m.MapId = types.StringValue(system.MapId)
+	m.Bookable = types.BoolValue(system.Bookable)
+	m.InstalledUiDevices = types.Int64Value(system.InstalledUiDevices)
+	m.Capacity = types.Int64Value(system.Capacity)
+	m.Images = stringList(ctx, system.Images, diags)
+	m.Zones = stringList(ctx, system.Zones, diags)
+	m.Modules = []systemModule{}
+	for _, id := range system.Modules {
+		m.Modules = append(m.Modules, systemModule{Id: types.StringValue(id)})
 	}
+	m.computedFromAPI(ctx, system, diags)
+}
 
-	d.SetId("")
-	return diags
+// computedFromAPI refreshes only the attributes computed by the engine.
+func (m *systemResourceModel) computedFromAPI(ctx context.Context, system *api.System, diags *diag.Diagnostics) {
+	m.Id = types.StringValue(system.Id)
+	m.Version = types.Int64Value(system.Version)
+	m.Features = stringList(ctx, system.Features, diags)
+	m.CreatedAt = types.Int64Value(system.CreatedAt)
+	m.UpdatedAt = types.Int64Value(system.UpdatedAt)
 }
@@ -0,0 +1,532 @@
+package placeos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/api"
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/internal/fakeengine"
+)
+
+func TestUnitSystem_basic(t *testing.T) {
+	engine := testFakeEngine(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testFakeCheckDestroy(engine, "systems", "placeos_system"),
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeProviderConfig(engine) + testSystemConfig("Meeting room 1", 8),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("placeos_system.test", "id"),
+					resource.TestCheckResourceAttr("placeos_system.test", "name", "Meeting room 1"),
+					resource.TestCheckResourceAttr("placeos_system.test", "capacity", "8"),
+					resource.TestCheckResourceAttrPair("placeos_system.test", "zones.0", "placeos_zone.test", "id"),
+				),
+			},
+			{
+				Config: testFakeProviderConfig(engine) + testSystemConfig("Meeting room 2", 12),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("placeos_system.test", "name", "Meeting room 2"),
+					resource.TestCheckResourceAttr("placeos_system.test", "capacity", "12"),
+				),
+			},
+			{
+				ResourceName:      "placeos_system.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestUnitSystem_modules(t *testing.T) {
+	engine := testFakeEngine(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testFakeCheckDestroy(engine, "systems", "placeos_system"),
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeProviderConfig(engine) + testSystemModulesConfig("a", "b"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("placeos_system.test", "module.#", "2"),
+					resource.TestCheckResourceAttrPair("placeos_system.test", "module.0.id", "placeos_module.a", "id"),
+					resource.TestCheckResourceAttrPair("placeos_system.test", "module.1.id", "placeos_module.b", "id"),
+				),
+			},
+			{
+				// a reorder is patched, the system is never replaced
+				Config: testFakeProviderConfig(engine) + testSystemModulesConfig("b", "a"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("placeos_system.test", "module.0.id", "placeos_module.b", "id"),
+					resource.TestCheckResourceAttrPair("placeos_system.test", "module.1.id", "placeos_module.a", "id"),
+					testFakeCheckSystemRequest(engine, http.MethodPatch, "", true),
+					testFakeCheckSystemRequest(engine, http.MethodPut, "", false),
+				),
+			},
+			{
+				// a is destroyed, which takes it out of the system, and c is
+				// added, b is left alone
+				Config: testFakeProviderConfig(engine) + testSystemModulesConfig("b", "c"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("placeos_system.test", "module.#", "2"),
+					resource.TestCheckResourceAttrPair("placeos_system.test", "module.0.id", "placeos_module.b", "id"),
+					resource.TestCheckResourceAttrPair("placeos_system.test", "module.1.id", "placeos_module.c", "id"),
+					testFakeCheckSystemRequest(engine, http.MethodPut, "/module/", true),
+					testFakeCheckSystemRequest(engine, http.MethodPut, "", false),
+					// running is unset, the modules are left as they are
+					testFakeCheckSystemRequest(engine, http.MethodPost, "/start", false),
+					testFakeCheckSystemRequest(engine, http.MethodPost, "/stop", false),
+				),
+			},
+			{
+				ResourceName:      "placeos_system.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestUnitSystem_running(t *testing.T) {
+	engine := testFakeEngine(t)
+	var moduleId string
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testFakeCheckDestroy(engine, "systems", "placeos_system"),
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeProviderConfig(engine) + testSystemRunningConfig(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccStoreID("placeos_module.a", &moduleId),
+					resource.TestCheckResourceAttr("placeos_system.test", "running", "false"),
+					testFakeCheckSystemRequest(engine, http.MethodPost, "/stop", true),
+				),
+			},
+			{
+				Config: testFakeProviderConfig(engine) + testSystemRunningConfig(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("placeos_system.test", "running", "true"),
+					testFakeCheckSystemRequest(engine, http.MethodPost, "/start", true),
+					testFakeCheckModuleRunning(engine, "placeos_module.a", true),
+					testFakeCheckModuleRunning(engine, "placeos_module.b", false),
+				),
+			},
+			{
+				// a module stopped outside of terraform is drift, apply
+				// starts the system again
+				PreConfig: func() { engine.Modify("modules", moduleId, map[string]interface{}{"running": false}) },
+				Config:    testFakeProviderConfig(engine) + testSystemRunningConfig(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("placeos_system.test", "running", "true"),
+					testFakeCheckModuleRunning(engine, "placeos_module.a", true),
+				),
+			},
+			{
+				ResourceName:      "placeos_system.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestSystemStateUpgradeV0(t *testing.T) {
+	req := fwresource.UpgradeStateRequest{
+		RawState: &tfprotov6.RawState{JSON: []byte(`{"id":"sys-1","name":"Room","modules":["mod-2","mod-1"]}`)},
+	}
+	resp := &fwresource.UpgradeStateResponse{}
+	upgradeSystemStateV0(context.Background(), req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var upgraded map[string]interface{}
+	if err := json.Unmarshal(resp.DynamicValue.JSON, &upgraded); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := upgraded["modules"]; ok {
+		t.Error("modules was not removed")
+	}
+	expected := []interface{}{
+		map[string]interface{}{"id": "mod-2"},
+		map[string]interface{}{"id": "mod-1"},
+	}
+	if !reflect.DeepEqual(upgraded["module"], expected) {
+		t.Errorf("module = %v, want %v", upgraded["module"], expected)
+	}
+}
+
+func TestSystemApplyChanges(t *testing.T) {
+	tests := map[string]struct {
+		remote   map[string]interface{}
+		failPut  bool
+		conflict bool
+		expected map[string]interface{}
+	}{
+		"remote edit of another attribute is kept": {
+			remote:   map[string]interface{}{"description": "Edited in Backoffice"},
+			expected: map[string]interface{}{"description": "Edited in Backoffice", "capacity": 16},
+		},
+		"remote edit matching the plan": {
+			remote:   map[string]interface{}{"capacity": 16},
+			expected: map[string]interface{}{"capacity": 16},
+		},
+		"remote edit of the same attribute": {
+			remote:   map[string]interface{}{"capacity": 20},
+			conflict: true,
+			expected: map[string]interface{}{"capacity": 20},
+		},
+		"stale version is retried": {
+			failPut:  true,
+			expected: map[string]interface{}{"capacity": 16},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			engine := testFakeEngine(t)
+			client, err := api.NewApiKeyClient(engine.URL, fakeengine.ApiKey, api.TLSOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			client.RetryMaxWait = 10 * time.Millisecond
+			r := &systemResource{client: client}
+
+			created, err := client.Systems.Create(ctx, &api.System{Name: "Meeting room", Capacity: 8})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var diags diag.Diagnostics
+			var state systemResourceModel
+			state.fromAPI(ctx, created, &diags)
+			plan := state
+			plan.Capacity = types.Int64Value(16)
+
+			if test.remote != nil {
+				engine.Modify("systems", created.Id, test.remote)
+			}
+			if test.failPut {
+				engine.Fail(http.MethodPut, "/api/engine/v2/systems/"+created.Id, 1, http.StatusConflict, `{"error":"version mismatch"}`)
+			}
+
+			system := r.applyChanges(ctx, &plan, &state, nil, &diags)
+			if test.conflict {
+				if !diags.HasError() || diags[0].Summary() != "System changed in PlaceOS" {
+					t.Fatalf("expected a conflict, got %v", diags)
+				}
+			} else {
+				if diags.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", diags)
+				}
+				if system.Capacity != 16 {
+					t.Errorf("capacity = %d, want 16", system.Capacity)
+				}
+			}
+
+			stored, _ := engine.Object("systems", created.Id)
+			for field, value := range test.expected {
+				if fmt.Sprint(stored[field]) != fmt.Sprint(value) {
+					t.Errorf("%s = %v, want %v", field, stored[field], value)
+				}
+			}
+		})
+	}
+}
+
+func TestUnitSystem_removeManagedModule(t *testing.T) {
+	engine := testFakeEngine(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testFakeCheckDestroy(engine, "systems", "placeos_system"),
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeProviderConfig(engine) + testSystemModulesConfig("a", "b"),
+			},
+			{
+				// a is still managed, taking it out of the system must not
+				// get it deleted by the engine, the running check fails on a
+				// missing module
+				Config: testFakeProviderConfig(engine) + testSystemModulesConfig("b") + `
+resource "placeos_module" "a" {
+  custom_name = "Lights a"
+  driver_id   = placeos_driver.test.id
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("placeos_system.test", "module.#", "1"),
+					resource.TestCheckResourceAttrPair("placeos_system.test", "module.0.id", "placeos_module.b", "id"),
+					testFakeCheckModuleRunning(engine, "placeos_module.a", false),
+					testFakeCheckSystemRequest(engine, http.MethodDelete, "/module/", false),
+				),
+			},
+			{
+				Config: testFakeProviderConfig(engine) + testSystemModulesConfig("b") + `
+resource "placeos_module" "a" {
+  custom_name = "Lights a"
+  driver_id   = placeos_driver.test.id
+}
+`,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+// testFakeCheckModuleRunning checks the running flag of a module in the fake
+// engine.
+func testFakeCheckModuleRunning(engine *fakeengine.Server, resourceName string, expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("%s not found in state", resourceName)
+		}
+
+		module, ok := engine.Object("modules", rs.Primary.ID)
+		if !ok {
+			return fmt.Errorf("%s not found in the engine", resourceName)
+		}
+		if running, _ := module["running"].(bool); running != expected {
+			return fmt.Errorf("%s running: %t, expected %t", resourceName, running, expected)
+		}
+
+		return nil
+	}
+}
+
+// testFakeCheckSystemRequest checks whether a request was sent for
+// placeos_system.test, suffix is appended to the system path and matches by
+// prefix.
+func testFakeCheckSystemRequest(engine *fakeengine.Server, method string, suffix string, expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources["placeos_system.test"]
+		if !ok {
+			return fmt.Errorf("placeos_system.test not found in state")
+		}
+
+		request := method + " /api/engine/v2/systems/" + rs.Primary.ID + suffix
+		found := false
+		for _, served := range engine.Requests() {
+			if served == request || (suffix != "" && strings.HasPrefix(served, request)) {
+				found = true
+			}
+		}
+		if found != expected {
+			return fmt.Errorf("request %q sent: %t, expected %t", request, found, expected)
+		}
+
+		return nil
+	}
+}
+
+func testSystemConfig(name string, capacity int) string {
+	return testZoneConfig("Building 1", 10) + fmt.Sprintf(`
+resource "placeos_system" "test" {
+  name     = %q
+  capacity = %d
+  zones    = [placeos_zone.test.id]
+}
+`, name, capacity)
+}
+
+// testSystemModulesConfig declares a module for each name and adds them to
+// the system in that order.
+func testSystemModulesConfig(names ...string) string {
+	config := testZoneConfig("Building 1", 10) + testDriverConfig("Lutron")
+	blocks := ""
+	for _, name := range names {
+		config += fmt.Sprintf(`
+resource "placeos_module" %[1]q {
+  custom_name = "Lights %[1]s"
+  driver_id   = placeos_driver.test.id
+}
+`, name)
+		blocks += fmt.Sprintf(`
+  module {
+    id = placeos_module.%s.id
+  }
+`, name)
+	}
+
+	return config + fmt.Sprintf(`
+resource "placeos_system" "test" {
+  name  = "Meeting room 1"
+  zones = [placeos_zone.test.id]
+%s}
+`, blocks)
+}
+
+// testSystemRunningConfig declares a system with two modules, the second
+// ignoring start and stop.
+func testSystemRunningConfig(running bool) string {
+	return testZoneConfig("Building 1", 10) + testDriverConfig("Lutron") + fmt.Sprintf(`
+resource "placeos_module" "a" {
+  custom_name = "Lights a"
+  driver_id   = placeos_driver.test.id
+}
+
+resource "placeos_module" "b" {
+  custom_name     = "Lights b"
+  driver_id       = placeos_driver.test.id
+  ignore_starstop = true
+}
+
+resource "placeos_system" "test" {
+  name    = "Meeting room 1"
+  zones   = [placeos_zone.test.id]
+  running = %t
+
+  module {
+    id = placeos_module.a.id
+  }
+
+  module {
+    id = placeos_module.b.id
+  }
+}
+`, running)
+}
+
+func TestAccSystem_basic(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-system")
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckSystemDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSystemConfig(name, 8),
+				Check: resource.ComposeTestCheckFunc(
+					testAccStoreID("placeos_system.test", &id),
+					resource.TestCheckResourceAttr("placeos_system.test", "name", name),
+					resource.TestCheckResourceAttr("placeos_system.test", "capacity", "8"),
+					resource.TestCheckResourceAttrPair("placeos_system.test", "zones.0", "placeos_zone.test", "id"),
+				),
+			},
+			{
+				Config: testAccSystemConfig(name+"-renamed", 12),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDUnchanged("placeos_system.test", &id),
+					resource.TestCheckResourceAttr("placeos_system.test", "name", name+"-renamed"),
+					resource.TestCheckResourceAttr("placeos_system.test", "capacity", "12"),
+				),
+			},
+			{
+				ResourceName:      "placeos_system.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccSystem_modules(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-system")
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckSystemDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSystemModulesConfig(name, "test", "second"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccStoreID("placeos_system.test", &id),
+					resource.TestCheckResourceAttrPair("placeos_system.test", "module.0.id", "placeos_module.test", "id"),
+					resource.TestCheckResourceAttrPair("placeos_system.test", "module.1.id", "placeos_module.second", "id"),
+				),
+			},
+			{
+				Config: testAccSystemModulesConfig(name, "second", "test"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDUnchanged("placeos_system.test", &id),
+					resource.TestCheckResourceAttrPair("placeos_system.test", "module.0.id", "placeos_module.second", "id"),
+					resource.TestCheckResourceAttrPair("placeos_system.test", "module.1.id", "placeos_module.test", "id"),
+				),
+			},
+			{
+				ResourceName:      "placeos_system.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckSystemDestroy(s *terraform.State) error {
+	return testAccCheckDestroy("placeos_system", func(ctx context.Context, c *api.Client, id string) error {
+		_, err := c.Systems.Get(ctx, id)
+		return err
+	})(s)
+}
+
+func testAccSystemConfig(name string, capacity int) string {
+	return fmt.Sprintf(`
+resource "placeos_zone" "test" {
+  name = "%[1]s-zone"
+  tags = ["building"]
+}
+
+resource "placeos_system" "test" {
+  name        = %[1]q
+  description = "Created by the terraform acceptance tests"
+  capacity    = %[2]d
+  zones       = [placeos_zone.test.id]
+}
+`, name, capacity)
+}
+
+// testAccSystemModulesConfig adds placeos_module.test and a second module of
+// the same driver to the system, in the given order.
+func testAccSystemModulesConfig(name string, first string, second string) string {
+	return testAccModuleConfig(name, "first", "Bookings") + fmt.Sprintf(`
+resource "placeos_module" "second" {
+  custom_name = "Bookings 2"
+  driver_id   = placeos_driver.first.id
+  uri         = "https://placeos.example.com"
+}
+
+resource "placeos_zone" "test" {
+  name = "%[1]s-zone"
+  tags = ["building"]
+}
+
+resource "placeos_system" "test" {
+  name  = %[1]q
+  zones = [placeos_zone.test.id]
+
+  module {
+    id = placeos_module.%[2]s.id
+  }
+
+  module {
+    id = placeos_module.%[3]s.id
+  }
+}
+`, name, first, second)
+}
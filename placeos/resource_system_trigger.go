@@ -0,0 +1,240 @@
+package placeos
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/api"
+)
+
+var (
+	_ resource.Resource                = &systemTriggerResource{}
+	_ resource.ResourceWithConfigure   = &systemTriggerResource{}
+	_ resource.ResourceWithImportState = &systemTriggerResource{}
+)
+
+type systemTriggerResource struct {
+	client *api.Client
+}
+
+type systemTriggerResourceModel struct {
+	Id            types.String   `tfsdk:"id"`
+	SystemId      types.String   `tfsdk:"system_id"`
+	TriggerId     types.String   `tfsdk:"trigger_id"`
+	Enabled       types.Bool     `tfsdk:"enabled"`
+	Important     types.Bool     `tfsdk:"important"`
+	ExecEnabled   types.Bool     `tfsdk:"exec_enabled"`
+	WebhookSecret types.String   `tfsdk:"webhook_secret"`
+	CreatedAt     types.Int64    `tfsdk:"created_at"`
+	UpdatedAt     types.Int64    `tfsdk:"updated_at"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+}
+
+func newSystemTriggerResource() resource.Resource {
+	return &systemTriggerResource{}
+}
+
+func (r *systemTriggerResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_system_trigger"
+}
+
+func (r *systemTriggerResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Binds an existing trigger to a system. Imported as `<system_id>/<id>`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"system_id": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"trigger_id": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"enabled": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"important": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"exec_enabled": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether the trigger can be fired through its webhook.",
+			},
+			"webhook_secret": schema.StringAttribute{
+				Computed:      true,
+				Sensitive:     true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+				Description:   "Secret of the webhook of this system's instance of the trigger, generated by the engine.",
+			},
+			"created_at": schema.Int64Attribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+			"updated_at": schema.Int64Attribute{
+				Computed: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *systemTriggerResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = clientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (r *systemTriggerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan systemTriggerResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	instance, err := r.client.SystemTriggers.Create(ctx, plan.SystemId.ValueString(), &api.TriggerInstance{
+		ControlSystemId: plan.SystemId.ValueString(),
+		TriggerId:       plan.TriggerId.ValueString(),
+		Enabled:         plan.Enabled.ValueBool(),
+		Important:       plan.Important.ValueBool(),
+		ExecEnabled:     plan.ExecEnabled.ValueBool(),
+	})
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+		return
+	}
+
+	plan.fromAPI(instance)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *systemTriggerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state systemTriggerResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	instance, err := r.client.SystemTriggers.Get(ctx, state.SystemId.ValueString(), state.Id.ValueString())
+	if api.IsNotFound(err) {
+		tflog.Warn(ctx, "system trigger not found, removing it from state", map[string]interface{}{"id": state.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+		return
+	}
+
+	state.fromAPI(instance)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *systemTriggerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan systemTriggerResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	instance, err := r.client.SystemTriggers.Update(ctx, plan.SystemId.ValueString(), plan.Id.ValueString(), &api.TriggerInstanceUpdate{
+		Enabled:     plan.Enabled.ValueBool(),
+		Important:   plan.Important.ValueBool(),
+		ExecEnabled: plan.ExecEnabled.ValueBool(),
+	})
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+		return
+	}
+
+	plan.fromAPI(instance)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *systemTriggerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state systemTriggerResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.client.SystemTriggers.Delete(ctx, state.SystemId.ValueString(), state.Id.ValueString())
+	if err != nil && !api.IsNotFound(err) {
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+	}
+}
+
+// ImportState takes "<system_id>/<id>", the instance is only reachable
+// through its system.
+func (r *systemTriggerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	systemId, id, ok := strings.Cut(req.ID, "/")
+	if !ok || systemId == "" || id == "" {
+		resp.Diagnostics.AddError("Invalid import ID", fmt.Sprintf("Expected <system_id>/<id>, got %q.", req.ID))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("system_id"), systemId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+func (m *systemTriggerResourceModel) fromAPI(instance *api.TriggerInstance) {
+	m.Id = types.StringValue(instance.Id)
+	m.SystemId = types.StringValue(instance.ControlSystemId)
+	m.TriggerId = types.StringValue(instance.TriggerId)
+	m.Enabled = types.BoolValue(instance.Enabled)
+	m.Important = types.BoolValue(instance.Important)
+	m.ExecEnabled = types.BoolValue(instance.ExecEnabled)
+	m.WebhookSecret = types.StringValue(instance.WebhookSecret)
+	m.CreatedAt = types.Int64Value(instance.CreatedAt)
+	m.UpdatedAt = types.Int64Value(instance.UpdatedAt)
+}
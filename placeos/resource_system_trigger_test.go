@@ -0,0 +1,160 @@
+package placeos
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/api"
+)
+
+func TestUnitSystemTrigger_basic(t *testing.T) {
+	engine := testFakeEngine(t)
+	triggerId := engine.Seed("triggers", map[string]interface{}{"name": "Occupancy"})
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testFakeCheckDestroy(engine, "trigger_instances", "placeos_system_trigger"),
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeProviderConfig(engine) + testSystemTriggerConfig(triggerId, true, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("placeos_system_trigger.test", "id"),
+					resource.TestCheckResourceAttrPair("placeos_system_trigger.test", "system_id", "placeos_system.test", "id"),
+					resource.TestCheckResourceAttr("placeos_system_trigger.test", "trigger_id", triggerId),
+					resource.TestCheckResourceAttr("placeos_system_trigger.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("placeos_system_trigger.test", "important", "false"),
+					resource.TestCheckResourceAttr("placeos_system_trigger.test", "exec_enabled", "false"),
+					resource.TestCheckResourceAttrSet("placeos_system_trigger.test", "webhook_secret"),
+				),
+			},
+			{
+				Config: testFakeProviderConfig(engine) + testSystemTriggerConfig(triggerId, false, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("placeos_system_trigger.test", "enabled", "false"),
+					resource.TestCheckResourceAttr("placeos_system_trigger.test", "important", "true"),
+				),
+			},
+			{
+				ResourceName:      "placeos_system_trigger.test",
+				ImportState:       true,
+				ImportStateIdFunc: testSystemTriggerImportID("placeos_system_trigger.test"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestUnitSystemTrigger_unknownTrigger(t *testing.T) {
+	engine := testFakeEngine(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testFakeProviderConfig(engine) + testSystemTriggerConfig("trigger-missing", true, false),
+				ExpectError: regexp.MustCompile(`Invalid trigger_id`),
+			},
+		},
+	})
+}
+
+func testSystemTriggerConfig(triggerId string, enabled bool, important bool) string {
+	return testSystemConfig("Meeting room 1", 8) + fmt.Sprintf(`
+resource "placeos_system_trigger" "test" {
+  system_id  = placeos_system.test.id
+  trigger_id = %q
+  enabled    = %t
+  important  = %t
+}
+`, triggerId, enabled, important)
+}
+
+// testSystemTriggerImportID builds the "<system_id>/<id>" import ID of a
+// system trigger.
+func testSystemTriggerImportID(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("%s not found in state", resourceName)
+		}
+
+		return rs.Primary.Attributes["system_id"] + "/" + rs.Primary.ID, nil
+	}
+}
+
+func TestAccSystemTrigger_basic(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-system-trigger")
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckSystemTriggerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSystemTriggerConfig(name, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccStoreID("placeos_system_trigger.test", &id),
+					resource.TestCheckResourceAttrPair("placeos_system_trigger.test", "trigger_id", "placeos_trigger.test", "id"),
+					resource.TestCheckResourceAttr("placeos_system_trigger.test", "important", "false"),
+				),
+			},
+			{
+				Config: testAccSystemTriggerConfig(name, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDUnchanged("placeos_system_trigger.test", &id),
+					resource.TestCheckResourceAttr("placeos_system_trigger.test", "important", "true"),
+				),
+			},
+			{
+				ResourceName:      "placeos_system_trigger.test",
+				ImportState:       true,
+				ImportStateIdFunc: testSystemTriggerImportID("placeos_system_trigger.test"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testAccCheckSystemTriggerDestroy looks up instances under their system,
+// they are not reachable by id alone.
+func testAccCheckSystemTriggerDestroy(s *terraform.State) error {
+	c, err := testAccClient()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "placeos_system_trigger" {
+			continue
+		}
+
+		_, err := c.SystemTriggers.Get(context.Background(), rs.Primary.Attributes["system_id"], rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("placeos_system_trigger %s still exists", rs.Primary.ID)
+		}
+		if !api.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccSystemTriggerConfig(name string, important bool) string {
+	return testAccSystemConfig(name, 8) + testAccTriggerConfig(name, "0 9 * * 1-5") + fmt.Sprintf(`
+resource "placeos_system_trigger" "test" {
+  system_id  = placeos_system.test.id
+  trigger_id = placeos_trigger.test.id
+  important  = %t
+}
+`, important)
+}
@@ -0,0 +1,593 @@
+package placeos
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/api"
+)
+
+var (
+	_ resource.Resource                = &triggerResource{}
+	_ resource.ResourceWithConfigure   = &triggerResource{}
+	_ resource.ResourceWithImportState = &triggerResource{}
+)
+
+// triggerOperators are the comparison operators understood by the engine.
+var triggerOperators = []string{
+	"equal", "not_equal",
+	"greater_than", "greater_than_or_equal",
+	"less_than", "less_than_or_equal",
+	"and", "or", "exclusive_or",
+}
+
+type triggerResource struct {
+	client *api.Client
+}
+
+type triggerResourceModel struct {
+	Id               types.String            `tfsdk:"id"`
+	Name             types.String            `tfsdk:"name"`
+	Description      types.String            `tfsdk:"description"`
+	DebouncePeriod   types.Int64             `tfsdk:"debounce_period"`
+	Important        types.Bool              `tfsdk:"important"`
+	EnableWebhook    types.Bool              `tfsdk:"enable_webhook"`
+	SupportedMethods types.List              `tfsdk:"supported_methods"`
+	Conditions       *triggerConditionsModel `tfsdk:"conditions"`
+	Actions          *triggerActionsModel    `tfsdk:"actions"`
+	CreatedAt        types.Int64             `tfsdk:"created_at"`
+	UpdatedAt        types.Int64             `tfsdk:"updated_at"`
+	Timeouts         timeouts.Value          `tfsdk:"timeouts"`
+}
+
+type triggerConditionsModel struct {
+	Comparisons    []triggerComparisonModel    `tfsdk:"comparisons"`
+	TimeDependents []triggerTimeDependentModel `tfsdk:"time_dependents"`
+}
+
+type triggerComparisonModel struct {
+	Left     *triggerValueModel `tfsdk:"left"`
+	Operator types.String       `tfsdk:"operator"`
+	Right    *triggerValueModel `tfsdk:"right"`
+}
+
+// triggerValueModel is either a constant, const, or a module status, mod
+// and status.
+type triggerValueModel struct {
+	Const  types.String `tfsdk:"const"`
+	Mod    types.String `tfsdk:"mod"`
+	Status types.String `tfsdk:"status"`
+	Keys   types.List   `tfsdk:"keys"`
+}
+
+type triggerTimeDependentModel struct {
+	Type     types.String `tfsdk:"type"`
+	Time     types.Int64  `tfsdk:"time"`
+	Cron     types.String `tfsdk:"cron"`
+	Timezone types.String `tfsdk:"timezone"`
+}
+
+type triggerActionsModel struct {
+	Functions []triggerFunctionModel `tfsdk:"functions"`
+	Mailers   []triggerMailerModel   `tfsdk:"mailers"`
+}
+
+type triggerFunctionModel struct {
+	Mod    types.String `tfsdk:"mod"`
+	Method types.String `tfsdk:"method"`
+	Args   types.String `tfsdk:"args"`
+}
+
+type triggerMailerModel struct {
+	Emails  types.List   `tfsdk:"emails"`
+	Content types.String `tfsdk:"content"`
+}
+
+func newTriggerResource() resource.Resource {
+	return &triggerResource{}
+}
+
+func (r *triggerResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_trigger"
+}
+
+func (r *triggerResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A trigger runs its actions when its conditions are met, in every system it is added to with `placeos_system_trigger`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+			"description": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
+			},
+			"debounce_period": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(0),
+				Validators:  []validator.Int64{int64validator.AtLeast(0)},
+				Description: "Milliseconds the conditions must hold before the actions run.",
+			},
+			"important": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"enable_webhook": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether the trigger can be fired through a webhook.",
+			},
+			"supported_methods": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Default:     listdefault.StaticValue(types.ListValueMust(types.StringType, []attr.Value{types.StringValue(http.MethodPost)})),
+				Validators: []validator.List{listvalidator.ValueStringsAre(stringvalidator.OneOf(
+					http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete,
+				))},
+				Description: "HTTP methods accepted by the webhook.",
+			},
+			"created_at": schema.Int64Attribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+			"updated_at": schema.Int64Attribute{
+				Computed: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"conditions": schema.SingleNestedBlock{
+				Description: "All conditions must be met for the actions to run.",
+				Blocks: map[string]schema.Block{
+					"comparisons": schema.ListNestedBlock{
+						Description: "Compares two values, each one a constant or a module status.",
+						NestedObject: schema.NestedBlockObject{
+							Attributes: map[string]schema.Attribute{
+								"operator": schema.StringAttribute{
+									Required:   true,
+									Validators: []validator.String{stringvalidator.OneOf(triggerOperators...)},
+								},
+							},
+							Blocks: map[string]schema.Block{
+								"left":  triggerValueBlock(),
+								"right": triggerValueBlock(),
+							},
+						},
+					},
+					"time_dependents": schema.ListNestedBlock{
+						Description: "Fires once at `time` for the `at` type, or on the `cron` schedule for the `cron` type.",
+						NestedObject: schema.NestedBlockObject{
+							Attributes: map[string]schema.Attribute{
+								"type": schema.StringAttribute{
+									Required:   true,
+									Validators: []validator.String{stringvalidator.OneOf("at", "cron")},
+								},
+								"time": schema.Int64Attribute{
+									Optional:    true,
+									Computed:    true,
+									Default:     int64default.StaticInt64(0),
+									Description: "Unix time in seconds, for the `at` type.",
+								},
+								"cron": schema.StringAttribute{
+									Optional:    true,
+									Computed:    true,
+									Default:     stringdefault.StaticString(""),
+									Description: "Cron expression, for the `cron` type.",
+								},
+								"timezone": schema.StringAttribute{
+									Optional:    true,
+									Computed:    true,
+									Default:     stringdefault.StaticString(""),
+									Description: "IANA timezone the cron expression is evaluated in.",
+								},
+							},
+						},
+					},
+				},
+			},
+			"actions": schema.SingleNestedBlock{
+				Description: "Run in order when the conditions are met.",
+				Blocks: map[string]schema.Block{
+					"functions": schema.ListNestedBlock{
+						Description: "Calls a method of a module of the system.",
+						NestedObject: schema.NestedBlockObject{
+							Attributes: map[string]schema.Attribute{
+								"mod": schema.StringAttribute{
+									Required:    true,
+									Description: "Module of the system, e.g. `Display_1`.",
+								},
+								"method": schema.StringAttribute{
+									Required: true,
+								},
+								"args": schema.StringAttribute{
+									Optional:    true,
+									Computed:    true,
+									Default:     stringdefault.StaticString("{}"),
+									Validators:  []validator.String{validJSON()},
+									Description: "Named arguments of the method as a JSON object, e.g. `jsonencode({ state = true })`.",
+								},
+							},
+						},
+					},
+					"mailers": schema.ListNestedBlock{
+						Description: "Sends an email.",
+						NestedObject: schema.NestedBlockObject{
+							Attributes: map[string]schema.Attribute{
+								"emails": schema.ListAttribute{
+									ElementType: types.StringType,
+									Required:    true,
+								},
+								"content": schema.StringAttribute{
+									Optional: true,
+									Computed: true,
+									Default:  stringdefault.StaticString(""),
+								},
+							},
+						},
+					},
+				},
+			},
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// triggerValueBlock is a side of a comparison, set either const or mod and
+// status.
+func triggerValueBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Validators: []validator.Object{objectvalidator.IsRequired()},
+		Attributes: map[string]schema.Attribute{
+			"const": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					validJSON(),
+					stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("mod")),
+				},
+				Description: "Constant as a JSON value, e.g. `jsonencode(true)`.",
+			},
+			"mod": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("status"))},
+				Description: "Module of the system whose status is compared, e.g. `Display_1`.",
+			},
+			"status": schema.StringAttribute{
+				Optional:   true,
+				Validators: []validator.String{stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("mod"))},
+			},
+			"keys": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Default:     listdefault.StaticValue(emptyStringList),
+				Description: "Path into the status value, for statuses holding an object.",
+			},
+		},
+	}
+}
+
+func (r *triggerResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = clientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (r *triggerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan triggerResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	trigger := &api.Trigger{}
+	plan.toAPI(ctx, trigger, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	trigger, err := r.client.Triggers.Create(ctx, trigger)
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+		return
+	}
+
+	plan.fromAPI(ctx, trigger, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *triggerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state triggerResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	trigger, err := r.client.Triggers.Get(ctx, state.Id.ValueString())
+	if api.IsNotFound(err) {
+		tflog.Warn(ctx, "trigger not found, removing it from state", map[string]interface{}{"id": state.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+		return
+	}
+
+	state.fromAPI(ctx, trigger, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *triggerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan triggerResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	trigger, err := r.client.Triggers.Get(ctx, plan.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+		return
+	}
+
+	plan.toAPI(ctx, trigger, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	trigger, err = r.client.Triggers.Update(ctx, trigger)
+	if err != nil {
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+		return
+	}
+
+	plan.fromAPI(ctx, trigger, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *triggerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state triggerResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.client.Triggers.Delete(ctx, state.Id.ValueString())
+	if err != nil && !api.IsNotFound(err) {
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+	}
+}
+
+func (r *triggerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// toAPI copies the planned attributes onto trigger, leaving the fields the
+// resource does not manage untouched.
+func (m *triggerResourceModel) toAPI(ctx context.Context, trigger *api.Trigger, diags *diag.Diagnostics) {
+	trigger.Name = m.Name.ValueString()
+	trigger.Description = m.Description.ValueString()
+	trigger.DebouncePeriod = m.DebouncePeriod.ValueInt64()
+	trigger.Important = m.Important.ValueBool()
+	trigger.EnableWebhook = m.EnableWebhook.ValueBool()
+	trigger.SupportedMethods = listStrings(ctx, m.SupportedMethods, diags)
+
+	trigger.Conditions = api.TriggerConditions{
+		Comparisons:    []api.TriggerComparison{},
+		TimeDependents: []api.TriggerTimeDependent{},
+	}
+	if m.Conditions != nil {
+		for _, comparison := range m.Conditions.Comparisons {
+			trigger.Conditions.Comparisons = append(trigger.Conditions.Comparisons, api.TriggerComparison{
+				Left:     comparison.Left.toAPI(ctx, diags),
+				Operator: comparison.Operator.ValueString(),
+				Right:    comparison.Right.toAPI(ctx, diags),
+			})
+		}
+		for _, timeDependent := range m.Conditions.TimeDependents {
+			trigger.Conditions.TimeDependents = append(trigger.Conditions.TimeDependents, api.TriggerTimeDependent{
+				Type:     timeDependent.Type.ValueString(),
+				Time:     timeDependent.Time.ValueInt64(),
+				Cron:     timeDependent.Cron.ValueString(),
+				Timezone: timeDependent.Timezone.ValueString(),
+			})
+		}
+	}
+
+	trigger.Actions = api.TriggerActions{
+		Functions: []api.TriggerFunction{},
+		Mailers:   []api.TriggerMailer{},
+	}
+	if m.Actions != nil {
+		for _, function := range m.Actions.Functions {
+			trigger.Actions.Functions = append(trigger.Actions.Functions, api.TriggerFunction{
+				Mod:    function.Mod.ValueString(),
+				Method: function.Method.ValueString(),
+				Args:   json.RawMessage(function.Args.ValueString()),
+			})
+		}
+		for _, mailer := range m.Actions.Mailers {
+			trigger.Actions.Mailers = append(trigger.Actions.Mailers, api.TriggerMailer{
+				Emails:  listStrings(ctx, mailer.Emails, diags),
+				Content: mailer.Content.ValueString(),
+			})
+		}
+	}
+}
+
+func (v *triggerValueModel) toAPI(ctx context.Context, diags *diag.Diagnostics) api.TriggerValue {
+	if v == nil {
+		return api.TriggerValue{}
+	}
+	if !v.Const.IsNull() {
+		return api.TriggerValue{Const: json.RawMessage(v.Const.ValueString())}
+	}
+
+	return api.TriggerValue{Status: &api.TriggerStatus{
+		Mod:    v.Mod.ValueString(),
+		Status: v.Status.ValueString(),
+		Keys:   listStrings(ctx, v.Keys, diags),
+	}}
+}
+
+// fromAPI sets the model from trigger. JSON values already in the model are
+// kept when the engine returns the same document. The conditions and
+// actions blocks stay unset when they are and the engine has none.
+func (m *triggerResourceModel) fromAPI(ctx context.Context, trigger *api.Trigger, diags *diag.Diagnostics) {
+	m.Id = types.StringValue(trigger.Id)
+	m.Name = types.StringValue(trigger.Name)
+	m.Description = types.StringValue(trigger.Description)
+	m.DebouncePeriod = types.Int64Value(trigger.DebouncePeriod)
+	m.Important = types.BoolValue(trigger.Important)
+	m.EnableWebhook = types.BoolValue(trigger.EnableWebhook)
+	m.SupportedMethods = stringList(ctx, trigger.SupportedMethods, diags)
+	m.CreatedAt = types.Int64Value(trigger.CreatedAt)
+	m.UpdatedAt = types.Int64Value(trigger.UpdatedAt)
+
+	conditions := trigger.Conditions
+	if m.Conditions != nil || len(conditions.Comparisons) > 0 || len(conditions.TimeDependents) > 0 {
+		prior := m.Conditions
+		if prior == nil {
+			prior = &triggerConditionsModel{}
+		}
+
+		m.Conditions = &triggerConditionsModel{
+			Comparisons:    []triggerComparisonModel{},
+			TimeDependents: []triggerTimeDependentModel{},
+		}
+		for i, comparison := range conditions.Comparisons {
+			var previous triggerComparisonModel
+			if i < len(prior.Comparisons) {
+				previous = prior.Comparisons[i]
+			}
+			m.Conditions.Comparisons = append(m.Conditions.Comparisons, triggerComparisonModel{
+				Left:     triggerValueFromAPI(ctx, previous.Left, comparison.Left, diags),
+				Operator: types.StringValue(comparison.Operator),
+				Right:    triggerValueFromAPI(ctx, previous.Right, comparison.Right, diags),
+			})
+		}
+		for _, timeDependent := range conditions.TimeDependents {
+			m.Conditions.TimeDependents = append(m.Conditions.TimeDependents, triggerTimeDependentModel{
+				Type:     types.StringValue(timeDependent.Type),
+				Time:     types.Int64Value(timeDependent.Time),
+				Cron:     types.StringValue(timeDependent.Cron),
+				Timezone: types.StringValue(timeDependent.Timezone),
+			})
+		}
+	}
+
+	actions := trigger.Actions
+	if m.Actions != nil || len(actions.Functions) > 0 || len(actions.Mailers) > 0 {
+		prior := m.Actions
+		if prior == nil {
+			prior = &triggerActionsModel{}
+		}
+
+		m.Actions = &triggerActionsModel{
+			Functions: []triggerFunctionModel{},
+			Mailers:   []triggerMailerModel{},
+		}
+		for i, function := range actions.Functions {
+			args := types.StringNull()
+			if i < len(prior.Functions) {
+				args = prior.Functions[i].Args
+			}
+			if len(function.Args) == 0 || string(function.Args) == "null" {
+				function.Args = json.RawMessage("{}")
+			}
+			m.Actions.Functions = append(m.Actions.Functions, triggerFunctionModel{
+				Mod:    types.StringValue(function.Mod),
+				Method: types.StringValue(function.Method),
+				Args:   jsonString(args, function.Args),
+			})
+		}
+		for _, mailer := range actions.Mailers {
+			m.Actions.Mailers = append(m.Actions.Mailers, triggerMailerModel{
+				Emails:  stringList(ctx, mailer.Emails, diags),
+				Content: types.StringValue(mailer.Content),
+			})
+		}
+	}
+}
+
+// triggerValueFromAPI converts a side of a comparison, prior is the same
+// side in the model, if any.
+func triggerValueFromAPI(ctx context.Context, prior *triggerValueModel, value api.TriggerValue, diags *diag.Diagnostics) *triggerValueModel {
+	if value.Status != nil {
+		return &triggerValueModel{
+			Const:  types.StringNull(),
+			Mod:    types.StringValue(value.Status.Mod),
+			Status: types.StringValue(value.Status.Status),
+			Keys:   stringList(ctx, value.Status.Keys, diags),
+		}
+	}
+
+	constant := types.StringNull()
+	if prior != nil {
+		constant = prior.Const
+	}
+
+	return &triggerValueModel{
+		Const:  jsonString(constant, value.Const),
+		Mod:    types.StringNull(),
+		Status: types.StringNull(),
+		Keys:   emptyStringList,
+	}
+}
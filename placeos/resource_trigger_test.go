@@ -0,0 +1,228 @@
+package placeos
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/api"
+)
+
+func TestUnitTrigger_basic(t *testing.T) {
+	engine := testFakeEngine(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testFakeCheckDestroy(engine, "triggers", "placeos_trigger"),
+		Steps: []resource.TestStep{
+			{
+				// args is not formatted the way the engine returns it.
+				Config: testFakeProviderConfig(engine) + testTriggerConfig("Lights on", `"{ \"state\": true }"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("placeos_trigger.test", "id"),
+					resource.TestCheckResourceAttr("placeos_trigger.test", "name", "Lights on"),
+					resource.TestCheckResourceAttr("placeos_trigger.test", "debounce_period", "1000"),
+					resource.TestCheckResourceAttr("placeos_trigger.test", "supported_methods.#", "1"),
+					resource.TestCheckResourceAttr("placeos_trigger.test", "supported_methods.0", "POST"),
+					resource.TestCheckResourceAttr("placeos_trigger.test", "conditions.comparisons.0.left.mod", "Occupancy_1"),
+					resource.TestCheckResourceAttr("placeos_trigger.test", "conditions.comparisons.0.left.keys.0", "people"),
+					resource.TestCheckNoResourceAttr("placeos_trigger.test", "conditions.comparisons.0.left.const"),
+					resource.TestCheckResourceAttr("placeos_trigger.test", "conditions.comparisons.0.right.const", "0"),
+					resource.TestCheckResourceAttr("placeos_trigger.test", "conditions.time_dependents.0.cron", "0 9 * * 1-5"),
+					resource.TestCheckResourceAttr("placeos_trigger.test", "actions.functions.0.args", `{ "state": true }`),
+					resource.TestCheckResourceAttr("placeos_trigger.test", "actions.mailers.0.emails.0", "facilities@example.com"),
+				),
+			},
+			{
+				Config: testFakeProviderConfig(engine) + testTriggerConfig("Lights off", `jsonencode({ state = false })`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("placeos_trigger.test", "name", "Lights off"),
+					resource.TestCheckResourceAttr("placeos_trigger.test", "actions.functions.0.args", `{"state":false}`),
+				),
+			},
+			{
+				ResourceName:      "placeos_trigger.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testFakeProviderConfig(engine) + `
+resource "placeos_trigger" "test" {
+  name = "Empty"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("placeos_trigger.test", "conditions.comparisons.#"),
+					resource.TestCheckNoResourceAttr("placeos_trigger.test", "actions.functions.#"),
+					resource.TestCheckResourceAttr("placeos_trigger.test", "enable_webhook", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitTrigger_invalidValue(t *testing.T) {
+	engine := testFakeEngine(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeProviderConfig(engine) + `
+resource "placeos_trigger" "test" {
+  name = "Invalid"
+
+  conditions {
+    comparisons {
+      left {
+        const = "on"
+      }
+      operator = "equal"
+      right {
+        const = "true"
+      }
+    }
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid JSON`),
+			},
+			{
+				Config: testFakeProviderConfig(engine) + `
+resource "placeos_trigger" "test" {
+  name = "Invalid"
+
+  conditions {
+    comparisons {
+      left {
+        const  = "1"
+        mod    = "Occupancy_1"
+        status = "people"
+      }
+      operator = "equal"
+      right {
+        const = "1"
+      }
+    }
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
+
+func testTriggerConfig(name string, args string) string {
+	return fmt.Sprintf(`
+resource "placeos_trigger" "test" {
+  name            = %q
+  debounce_period = 1000
+  enable_webhook  = true
+
+  conditions {
+    comparisons {
+      left {
+        mod    = "Occupancy_1"
+        status = "presence"
+        keys   = ["people"]
+      }
+      operator = "greater_than"
+      right {
+        const = jsonencode(0)
+      }
+    }
+
+    time_dependents {
+      type     = "cron"
+      cron     = "0 9 * * 1-5"
+      timezone = "Australia/Sydney"
+    }
+  }
+
+  actions {
+    functions {
+      mod    = "Lights_1"
+      method = "power"
+      args   = %s
+    }
+
+    mailers {
+      emails  = ["facilities@example.com"]
+      content = "Lights switched"
+    }
+  }
+}
+`, name, args)
+}
+
+func TestAccTrigger_basic(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-trigger")
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckTriggerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTriggerConfig(name, "0 9 * * 1-5"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccStoreID("placeos_trigger.test", &id),
+					resource.TestCheckResourceAttr("placeos_trigger.test", "name", name),
+					resource.TestCheckResourceAttr("placeos_trigger.test", "conditions.time_dependents.0.cron", "0 9 * * 1-5"),
+				),
+			},
+			{
+				Config: testAccTriggerConfig(name, "0 8 * * 1-5"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDUnchanged("placeos_trigger.test", &id),
+					resource.TestCheckResourceAttr("placeos_trigger.test", "conditions.time_dependents.0.cron", "0 8 * * 1-5"),
+				),
+			},
+			{
+				ResourceName:      "placeos_trigger.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckTriggerDestroy(s *terraform.State) error {
+	return testAccCheckDestroy("placeos_trigger", func(ctx context.Context, c *api.Client, id string) error {
+		_, err := c.Triggers.Get(ctx, id)
+		return err
+	})(s)
+}
+
+func testAccTriggerConfig(name string, cron string) string {
+	return fmt.Sprintf(`
+resource "placeos_trigger" "test" {
+  name        = %q
+  description = "Created by the terraform acceptance tests"
+
+  conditions {
+    time_dependents {
+      type     = "cron"
+      cron     = %q
+      timezone = "UTC"
+    }
+  }
+
+  actions {
+    mailers {
+      emails  = ["facilities@example.com"]
+      content = "Good morning"
+    }
+  }
+}
+`, name, cron)
+}
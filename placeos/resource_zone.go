@@ -2,242 +2,407 @@ package placeos
 
 import (
 	"context"
-	"encoding/json"
-	"os"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/api"
 )
 
-func resourceZone() *schema.Resource {
-	return &schema.Resource{
-		CreateContext: resourceZoneCreate,
-		ReadContext:   resourceZoneRead,
-		UpdateContext: resourceZoneUpdate,
-		DeleteContext: resourceZoneDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+var (
+	_ resource.Resource                = &zoneResource{}
+	_ resource.ResourceWithConfigure   = &zoneResource{}
+	_ resource.ResourceWithImportState = &zoneResource{}
+	_ resource.ResourceWithModifyPlan  = &zoneResource{}
+)
 
-		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:     schema.TypeString,
-				Required: true,
+// zoneParentTags lists, for each tag of the zone hierarchy, the tags its
+// parent may carry. Zones tagged org or outside the hierarchy are not
+// checked.
+var zoneParentTags = map[string][]string{
+	"region":   {"org"},
+	"building": {"region", "org"},
+	"level":    {"building"},
+}
+
+// zoneHierarchy are the tags PlaceOS apps use to structure zones, from the
+// top.
+var zoneHierarchy = []string{"org", "region", "building", "level"}
+
+type zoneResource struct {
+	client *api.Client
+}
+
+type zoneResourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Tags        types.Set    `tfsdk:"tags"`
+	Description types.String `tfsdk:"description"`
+	DisplayName types.String `tfsdk:"display_name"`
+	Code        types.String `tfsdk:"code"`
+	Type        types.String `tfsdk:"type"`
+	Location    types.String `tfsdk:"location"`
+	CountField  types.Int64  `tfsdk:"count_field"`
+	Capacity    types.Int64  `tfsdk:"capacity"`
+	MapId       types.String `tfsdk:"map_id"`
+	ParentId    types.String `tfsdk:"parent_id"`
+	// ValidateHierarchy only lives in the configuration, the engine does
+	// not know about it.
+	ValidateHierarchy types.Bool     `tfsdk:"validate_hierarchy"`
+	CreatedAt         types.Int64    `tfsdk:"created_at"`
+	UpdatedAt         types.Int64    `tfsdk:"updated_at"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
+}
+
+func newZoneResource() resource.Resource {
+	return &zoneResource{}
+}
+
+func (r *zoneResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone"
+}
+
+func (r *zoneResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
-			"tags": {
-				Type: schema.TypeList,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
+			"name": schema.StringAttribute{
 				Required: true,
 			},
-			"description": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  nil,
+			"tags": schema.SetAttribute{
+				ElementType: types.StringType,
+				Required:    true,
 			},
-			"display_name": {
-				Type:     schema.TypeString,
+			"description": schema.StringAttribute{
 				Optional: true,
-				Default:  nil,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
 			},
-			"code": {
-				Type:     schema.TypeString,
+			"display_name": schema.StringAttribute{
 				Optional: true,
-				Default:  nil,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
 			},
-			"type": {
-				Type:     schema.TypeString,
+			"code": schema.StringAttribute{
 				Optional: true,
-				Default:  nil,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
 			},
-			"location": {
-				Type:     schema.TypeString,
+			"type": schema.StringAttribute{
 				Optional: true,
-				Default:  nil,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
 			},
-			"count_field": {
-				Type:     schema.TypeInt,
+			"location": schema.StringAttribute{
 				Optional: true,
-				Default:  nil,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
 			},
-			"capacity": {
-				Type:     schema.TypeInt,
+			"count_field": schema.Int64Attribute{
 				Optional: true,
-				Default:  nil,
+				Computed: true,
+				Default:  int64default.StaticInt64(0),
 			},
-			"map_id": {
-				Type:     schema.TypeString,
+			"capacity": schema.Int64Attribute{
 				Optional: true,
-				Default:  nil,
+				Computed: true,
+				Default:  int64default.StaticInt64(0),
 			},
-			"parent_id": {
-				Type:     schema.TypeString,
+			"map_id": schema.StringAttribute{
 				Optional: true,
-				Default:  nil,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
 			},
-			"id": {
-				Type:     schema.TypeString,
+			"parent_id": schema.StringAttribute{
+				Optional: true,
 				Computed: true,
+				Default:  stringdefault.StaticString(""),
 			},
-			"created_at": {
-				Type:     schema.TypeInt,
+			"validate_hierarchy": schema.BoolAttribute{
+				Optional: true,
 				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				Description: "Check that the parent of a `region`, `building` or `level` zone carries the tag above it in the " +
+					"`org` > `region` > `building` > `level` hierarchy. Checked at plan time, or at apply time while `parent_id` is unknown.",
+			},
+			"created_at": schema.Int64Attribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
 			},
-			"updated_at": {
-				Type:     schema.TypeInt,
+			"updated_at": schema.Int64Attribute{
 				Computed: true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
-// "name" "description" "display_name" "code" "type" "count" "capacity" "map_id" "parent_id" "tags"
+func (r *zoneResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = clientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (r *zoneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan zoneResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-func resourceZoneCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	// Warning or errors can be collected in a slice type
-	c := m.(*Client)
+	createTimeout, diags := plan.Timeouts.Create(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
-	name := d.Get("name").(string)
-	description := d.Get("description").(string)
-	display_name := d.Get("display_name").(string)
-	code := d.Get("code").(string)
-	type_ := d.Get("type").(string)
-	count := d.Get("count_field").(int)
-	capacity := d.Get("capacity").(int)
-	location := d.Get("location").(string)
-	map_id := d.Get("map_id").(string)
-	parent_id := d.Get("parent_id").(string)
-	tags_interface := d.Get("tags")
+	r.checkHierarchy(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	tags := make([]string, len(tags_interface.([]interface{})))
-	for i, v := range tags_interface.([]interface{}) {
-		tags[i] = v.(string)
+	zone := &api.Zone{}
+	plan.toAPI(ctx, zone, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	setting, err := c.CreateZone(name, description, tags, location, display_name, code, type_, count, capacity, map_id, parent_id)
+	zone, err := r.client.Zones.Create(ctx, zone)
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+		return
 	}
 
-	d.SetId(setting.Id)
-	return resourceZoneRead(ctx, d, m)
+	plan.fromAPI(ctx, zone, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
-// add a resource driver read function with diagnostic tool for each field
-func resourceZoneRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	// Warning or errors can be collected in a slice type
-	c := m.(*Client)
-	var diags diag.Diagnostics
-	id := d.Get("id").(string)
-	zone, err := c.GetZone(id)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	d.Set("name", zone.Name)
-	d.Set("description", zone.Description)
-	d.Set("display_name", zone.DisplayName)
-	d.Set("code", zone.Code)
-	d.Set("type", zone.Type)
-	d.Set("count_field", zone.Count)
-	d.Set("capacity", zone.Capacity)
-	d.Set("location", zone.Location)
-	d.Set("map_id", zone.MapId)
-	d.Set("parent_id", zone.ParentId)
-	d.Set("tags", zone.Tags)
-	d.Set("id", zone.Id)
-	d.Set("created_at", zone.CreatedAt)
-	d.Set("updated_at", zone.UpdatedAt)
-
-	return diags
-}
-
-// add a resource driver update function with diagnostic tool for each field
-func resourceZoneUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	// Warning or errors can be collected in a slice type
-	c := m.(*Client)
-	id := d.Get("id").(string)
-	zone, err := c.GetZone(id)
-	if err != nil {
-		return diag.FromErr(err)
+func (r *zoneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state zoneResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zone, err := r.client.Zones.Get(ctx, state.Id.ValueString())
+	if api.IsNotFound(err) {
+		tflog.Warn(ctx, "zone not found, removing it from state", map[string]interface{}{"id": state.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
 	}
-	file, err := os.Create("/tmp/zone.json")
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+		return
 	}
 
-	text, err := json.Marshal(zone)
+	state.fromAPI(ctx, zone, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *zoneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan zoneResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	r.checkHierarchy(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
+	zone, err := r.client.Zones.Get(ctx, plan.Id.ValueString())
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+		return
 	}
+	ctx = logContext(ctx)
+	tflog.Debug(ctx, "read zone before update", logFields(zone))
 
-	file.Write(text)
-	file.Close()
+	plan.toAPI(ctx, zone, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
+	zone, err = r.client.Zones.Update(ctx, zone)
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
+		return
+	}
+
+	plan.fromAPI(ctx, zone, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *zoneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state zoneResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	// check each field has change and replace it if it has
 
-	zone.Id = id
-	if d.HasChange("name") {
-		zone.Name = d.Get("name").(string)
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if d.HasChange("description") {
-		zone.Description = d.Get("description").(string)
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.client.Zones.Delete(ctx, state.Id.ValueString())
+	if err != nil && !api.IsNotFound(err) {
+		resp.Diagnostics.Append(diagnosticsFromErr(ctx, err, resp.State.Schema)...)
 	}
-	if d.HasChange("display_name") {
-		zone.DisplayName = d.Get("display_name").(string)
+}
+
+// ModifyPlan reports a zone placed under the wrong parent before anything
+// is applied, when validate_hierarchy is set and the parent already exists.
+func (r *zoneResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
 	}
-	if d.HasChange("code") {
-		zone.Code = d.Get("code").(string)
+
+	var plan zoneResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.ParentId.IsUnknown() || plan.Tags.IsUnknown() {
+		return
 	}
-	if d.HasChange("type") {
-		zone.Type = d.Get("type").(string)
+
+	r.checkHierarchy(ctx, &plan, &resp.Diagnostics)
+}
+
+// checkHierarchy verifies the tags of the parent of a zone against
+// zoneParentTags when validate_hierarchy is set.
+func (r *zoneResource) checkHierarchy(ctx context.Context, plan *zoneResourceModel, diags *diag.Diagnostics) {
+	if !plan.ValidateHierarchy.ValueBool() {
+		return
 	}
-	if d.HasChange("count") {
-		zone.Count = d.Get("count").(int)
+
+	tags := hierarchyTags(setStrings(ctx, plan.Tags, diags))
+	if len(tags) > 1 {
+		diags.AddAttributeError(
+			path.Root("tags"),
+			"Ambiguous zone hierarchy",
+			fmt.Sprintf("A zone carries at most one of %s, got %s.", strings.Join(zoneHierarchy, ", "), strings.Join(tags, " and ")),
+		)
+		return
 	}
-	if d.HasChange("capacity") {
-		zone.Capacity = d.Get("capacity").(int)
+	if len(tags) == 0 || plan.ParentId.ValueString() == "" {
+		return
 	}
-	if d.HasChange("location") {
-		zone.Location = d.Get("location").(string)
+	allowed, ok := zoneParentTags[tags[0]]
+	if !ok {
+		return
 	}
-	if d.HasChange("map_id") {
-		zone.MapId = d.Get("map_id").(string)
+
+	parent, err := r.client.Zones.Get(ctx, plan.ParentId.ValueString())
+	if api.IsNotFound(err) {
+		diags.AddAttributeError(path.Root("parent_id"), "Unknown parent zone", fmt.Sprintf("Parent zone %s does not exist.", plan.ParentId.ValueString()))
+		return
 	}
-	if d.HasChange("parent_id") {
-		zone.ParentId = d.Get("parent_id").(string)
+	if err != nil {
+		diags.Append(diagnosticsFromErr(ctx, err, nil)...)
+		return
 	}
-	if d.HasChange("tags") {
-		tags := make([]string, len(d.Get("tags").([]interface{})))
-		for i, v := range d.Get("tags").([]interface{}) {
-			tags[i] = v.(string)
+
+	parentTags := hierarchyTags(parent.Tags)
+	for _, tag := range parentTags {
+		if slices.Contains(allowed, tag) {
+			return
 		}
-		zone.Tags = tags
 	}
 
-	zone, err = c.UpdateZone(zone)
-	if err != nil {
-		return diag.FromErr(err)
+	found := "no hierarchy tag"
+	if len(parentTags) > 0 {
+		found = strings.Join(parentTags, " and ")
+	}
+	diags.AddAttributeError(
+		path.Root("parent_id"),
+		"Invalid zone hierarchy",
+		fmt.Sprintf("A %s zone must sit under a %s zone, but parent %q (%s) has %s.", tags[0], strings.Join(allowed, " or "), parent.Name, parent.Id, found),
+	)
+}
+
+// hierarchyTags returns the tags of the zone hierarchy found in tags.
+func hierarchyTags(tags []string) []string {
+	var found []string
+	for _, tag := range zoneHierarchy {
+		if slices.Contains(tags, tag) {
+			found = append(found, tag)
+		}
 	}
 
-	return resourceZoneRead(ctx, d, m)
+	return found
 }
 
-// add a resource driver delete function with diagnostic as return
-func resourceZoneDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*Client)
-	var diags diag.Diagnostics
+func (r *zoneResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
 
-	id := d.Get("id").(string)
-	err := c.deleteZone(id)
+// toAPI copies the planned attributes onto zone, leaving the fields the
+// resource does not manage untouched.
+func (m *zoneResourceModel) toAPI(ctx context.Context, zone *api.Zone, diags *diag.Diagnostics) {
+	zone.Name = m.Name.ValueString()
+	zone.Tags = setStrings(ctx, m.Tags, diags)
+	zone.Description = m.Description.ValueString()
+	zone.DisplayName = m.DisplayName.ValueString()
+	zone.Code = m.Code.ValueString()
+	zone.Type = m.Type.ValueString()
+	zone.Location = m.Location.ValueString()
+	zone.Count = int(m.CountField.ValueInt64())
+	zone.Capacity = int(m.Capacity.ValueInt64())
+	zone.MapId = m.MapId.ValueString()
+	zone.ParentId = m.ParentId.ValueString()
+}
 
-	if err != nil {
-		return diag.FromErr(err)
+func (m *zoneResourceModel) fromAPI(ctx context.Context, zone *api.Zone, diags *diag.Diagnostics) {
+	m.Id = types.StringValue(zone.Id)
+	m.Name = types.StringValue(zone.Name)
+	m.Tags = stringSet(ctx, zone.Tags, diags)
+	m.Description = types.StringValue(zone.Description)
+	m.DisplayName = types.StringValue(zone.DisplayName)
+	m.Code = types.StringValue(zone.Code)
+	m.Type = types.StringValue(zone.Type)
+	m.Location = types.StringValue(zone.Location)
+	m.CountField = types.Int64Value(int64(zone.Count))
+	m.Capacity = types.Int64Value(int64(zone.Capacity))
+	m.MapId = types.StringValue(zone.MapId)
+	m.ParentId = types.StringValue(zone.ParentId)
+	if m.ValidateHierarchy.IsNull() {
+		m.ValidateHierarchy = types.BoolValue(false)
 	}
-
-	return diags
+	m.CreatedAt = types.Int64Value(zone.CreatedAt)
+	m.UpdatedAt = types.Int64Value(zone.UpdatedAt)
 }
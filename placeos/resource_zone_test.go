@@ -0,0 +1,295 @@
+package placeos
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/api"
+	"github.com/cacique-coder/terraform-placeos-provider/placeos/internal/fakeengine"
+)
+
+func TestUnitZone_basic(t *testing.T) {
+	engine := testFakeEngine(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testFakeCheckDestroy(engine, "zones", "placeos_zone"),
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeProviderConfig(engine) + testZoneConfig("Building 1", 10),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("placeos_zone.test", "id"),
+					resource.TestCheckResourceAttr("placeos_zone.test", "name", "Building 1"),
+					resource.TestCheckResourceAttr("placeos_zone.test", "capacity", "10"),
+					resource.TestCheckTypeSetElemAttr("placeos_zone.test", "tags.*", "building"),
+				),
+			},
+			{
+				Config: testFakeProviderConfig(engine) + testZoneConfig("Building 2", 20),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("placeos_zone.test", "name", "Building 2"),
+					resource.TestCheckResourceAttr("placeos_zone.test", "capacity", "20"),
+				),
+			},
+			{
+				ResourceName:      "placeos_zone.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestUnitZone_removedRemotely(t *testing.T) {
+	engine := testFakeEngine(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeProviderConfig(engine) + testZoneConfig("Building 1", 10),
+			},
+			{
+				PreConfig:          func() { testFakeRemoveAll(engine, "zones") },
+				Config:             testFakeProviderConfig(engine) + testZoneConfig("Building 1", 10),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestUnitZone_validationError(t *testing.T) {
+	engine := testFakeEngine(t)
+	engine.Fail(http.MethodPost, "/api/engine/v2/zones", 1, http.StatusUnprocessableEntity, `{"error":"validation failed","failures":[{"field":"parent_id","reason":"does not exist"}]}`)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testFakeProviderConfig(engine) + testZoneConfig("Building 1", 10),
+				ExpectError: regexp.MustCompile(`Invalid parent_id`),
+			},
+		},
+	})
+}
+
+func TestUnitZone_serverError(t *testing.T) {
+	engine := testFakeEngine(t)
+	engine.Fail(http.MethodPost, "/api/engine/v2/zones", 1, http.StatusInternalServerError, `{"error":"boom"}`)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testFakeProviderConfig(engine) + testZoneConfig("Building 1", 10),
+				ExpectError: regexp.MustCompile(`500 boom`),
+			},
+		},
+	})
+}
+
+func TestUnitZone_reauthorizes(t *testing.T) {
+	engine := testFakeEngine(t)
+
+	config := fmt.Sprintf(`
+provider "placeos" {
+  host          = %q
+  username      = %q
+  password      = %q
+  client_id     = %q
+  client_secret = %q
+}
+`, engine.URL, fakeengine.Username, fakeengine.Password, fakeengine.ClientId, fakeengine.ClientSecret)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					engine.Fail(http.MethodPost, "/api/engine/v2/zones", 1, http.StatusUnauthorized, `{"error":"token expired"}`)
+				},
+				Config: config + testZoneConfig("Building 1", 10),
+				Check:  resource.TestCheckResourceAttrSet("placeos_zone.test", "id"),
+			},
+		},
+	})
+}
+
+func TestUnitZone_tagsOrder(t *testing.T) {
+	engine := testFakeEngine(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeProviderConfig(engine) + testZoneTagsConfig(`["building", "sydney"]`),
+				Check:  resource.TestCheckResourceAttr("placeos_zone.test", "tags.#", "2"),
+			},
+			{
+				Config:   testFakeProviderConfig(engine) + testZoneTagsConfig(`["sydney", "building"]`),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestUnitZone_hierarchy(t *testing.T) {
+	engine := testFakeEngine(t)
+	orgId := engine.Seed("zones", map[string]interface{}{"name": "PlaceOS", "tags": []string{"org"}})
+	buildingId := engine.Seed("zones", map[string]interface{}{"name": "Building 1", "tags": []string{"building"}})
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testFakeProviderConfig(engine) + testZoneHierarchyConfig(`"level"`, fmt.Sprintf("%q", orgId)),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`A level zone must sit under a building zone, but parent "PlaceOS"`),
+			},
+			{
+				Config:      testFakeProviderConfig(engine) + testZoneHierarchyConfig(`"level", "building"`, fmt.Sprintf("%q", buildingId)),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Ambiguous zone hierarchy`),
+			},
+			{
+				// the parent is created in the same apply, so the check
+				// can only run once its id is known
+				Config: testFakeProviderConfig(engine) + testZoneHierarchyConfig(`"level"`, "placeos_zone.parent.id") + `
+resource "placeos_zone" "parent" {
+  name = "Region 1"
+  tags = ["region"]
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid zone hierarchy`),
+			},
+			{
+				Config: testFakeProviderConfig(engine) + testZoneHierarchyConfig(`"level"`, fmt.Sprintf("%q", buildingId)),
+				Check:  resource.TestCheckResourceAttr("placeos_zone.test", "parent_id", buildingId),
+			},
+		},
+	})
+}
+
+func testZoneTagsConfig(tags string) string {
+	return fmt.Sprintf(`
+resource "placeos_zone" "test" {
+  name = "Building 1"
+  tags = %s
+}
+`, tags)
+}
+
+func testZoneHierarchyConfig(tags string, parentId string) string {
+	return fmt.Sprintf(`
+resource "placeos_zone" "test" {
+  name               = "Level 1"
+  tags               = [%s]
+  parent_id          = %s
+  validate_hierarchy = true
+}
+`, tags, parentId)
+}
+
+func testZoneConfig(name string, capacity int) string {
+	return fmt.Sprintf(`
+resource "placeos_zone" "test" {
+  name     = %q
+  capacity = %d
+  tags     = ["building"]
+}
+`, name, capacity)
+}
+
+// testFakeCheckDestroy verifies every resourceType left in state is gone
+// from the fake engine collection.
+func testFakeCheckDestroy(engine *fakeengine.Server, collection string, resourceType string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+			if _, ok := engine.Object(collection, rs.Primary.ID); ok {
+				return fmt.Errorf("%s %s still exists", resourceType, rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
+
+// testFakeRemoveAll deletes a whole collection behind the provider's back.
+func testFakeRemoveAll(engine *fakeengine.Server, collection string) {
+	for _, id := range engine.IDs(collection) {
+		engine.Remove(collection, id)
+	}
+}
+
+func TestAccZone_basic(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-zone")
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckZoneDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZoneConfig(name, 10, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccStoreID("placeos_zone.test", &id),
+					resource.TestCheckResourceAttr("placeos_zone.test", "name", name),
+					resource.TestCheckResourceAttr("placeos_zone.test", "capacity", "10"),
+					resource.TestCheckResourceAttr("placeos_zone.test", "count_field", "1"),
+				),
+			},
+			{
+				Config: testAccZoneConfig(name+"-renamed", 20, 2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDUnchanged("placeos_zone.test", &id),
+					resource.TestCheckResourceAttr("placeos_zone.test", "name", name+"-renamed"),
+					resource.TestCheckResourceAttr("placeos_zone.test", "capacity", "20"),
+					resource.TestCheckResourceAttr("placeos_zone.test", "count_field", "2"),
+				),
+			},
+			{
+				ResourceName:      "placeos_zone.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckZoneDestroy(s *terraform.State) error {
+	return testAccCheckDestroy("placeos_zone", func(ctx context.Context, c *api.Client, id string) error {
+		_, err := c.Zones.Get(ctx, id)
+		return err
+	})(s)
+}
+
+func testAccZoneConfig(name string, capacity int, count int) string {
+	return fmt.Sprintf(`
+resource "placeos_zone" "test" {
+  name        = %q
+  description = "Created by the terraform acceptance tests"
+  capacity    = %d
+  count_field = %d
+  tags        = ["building"]
+}
+`, name, capacity, count)
+}
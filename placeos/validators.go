@@ -0,0 +1,37 @@
+package placeos
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = jsonValidator{}
+
+// jsonValidator checks that a string attribute holds a JSON document,
+// usually built with jsonencode.
+type jsonValidator struct{}
+
+func validJSON() validator.String {
+	return jsonValidator{}
+}
+
+func (v jsonValidator) Description(ctx context.Context) string {
+	return "value must be a JSON document"
+}
+
+func (v jsonValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v jsonValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(req.ConfigValue.ValueString()), &value); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid JSON", "Expected a JSON document, e.g. built with jsonencode: "+err.Error())
+	}
+}